@@ -2,11 +2,13 @@ module github.com/AnatoleLucet/sig
 
 go 1.25.1
 
-require github.com/petermattis/goid v0.0.0-20251121121749-a11dd1a45f9a
+require (
+	github.com/petermattis/goid v0.0.0-20251121121749-a11dd1a45f9a
+	github.com/stretchr/testify v1.11.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.11.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
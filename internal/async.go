@@ -0,0 +1,237 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+)
+
+// AsyncResult is the value held by an async computed once its computation has resolved.
+type AsyncResult struct {
+	Value any
+	Err   error
+}
+
+type AsyncComputed struct {
+	*Computed
+
+	// the runtime the node was created in, results are committed back to it
+	runtime *Runtime
+
+	// the suspense boundary the node is in, if any
+	boundary *Suspense
+
+	// the computation currently in flight, results of any other run are discarded
+	current *asyncRun
+
+	// runtime the runs are executed within, kept between runs, nil while a run is using it
+	idle *Runtime
+
+	compute func(context.Context) (any, error)
+}
+
+type asyncRun struct {
+	// cancelled when the node recomputes or is disposed
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// the runtime bound to the worker goroutine, the nodes created by the computation belong to it
+	runtime *Runtime
+	tracker *Tracker
+
+	// owns the cleanups and nodes created by the computation, disposed once the run is both stopped and finished
+	owner *Owner
+
+	stopped  bool
+	finished bool
+}
+
+func (r *Runtime) NewAsyncComputed(compute func(context.Context) (any, error)) *AsyncComputed {
+	a := &AsyncComputed{
+		Computed: r.newComputed(nil),
+		runtime:  r,
+		compute:  compute,
+	}
+
+	a.eager = true
+	a.Signal.value = AsyncResult{}
+	a.SetPredicate(asyncPredicate)
+	a.boundary = a.Owner.Boundary()
+
+	a.mu.Lock()
+	a.fn = a.run
+	a.detach = a.stopCurrent
+	a.mu.Unlock()
+
+	a.OnDispose(func() {
+		a.stopCurrent()
+		a.RemoveFlag(FlagPending)
+		a.syncBoundary()
+	})
+
+	r.Run(func() { r.recompute(a.Computed) })
+
+	return a
+}
+
+func (a *AsyncComputed) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &asyncRun{ctx: ctx, cancel: cancel, tracker: NewTracker()}
+
+	a.mu.Lock()
+	shouldCleanup := a.initialized
+	a.initialized = true
+
+	// the previous run was stopped by stopCurrent before the node's dependencies were cleared
+	a.current = run
+	a.AddFlag(FlagPending)
+	a.mu.Unlock()
+
+	a.syncBoundary()
+
+	if shouldCleanup {
+		a.Cleanup()
+	}
+
+	// taken once the previous run is stopped, so that its runtime is reused if it finished
+	a.mu.Lock()
+	run.runtime = a.idle
+	a.idle = nil
+	a.mu.Unlock()
+
+	if run.runtime == nil {
+		run.runtime = NewRuntime()
+	}
+
+	// the runtime is only used by this run until it is released
+	run.runtime.tracker = run.tracker
+	run.owner = run.runtime.NewOwner()
+
+	go a.execute(run)
+}
+
+// execute runs the computation on the current goroutine, then commits its result to the node's runtime.
+func (a *AsyncComputed) execute(run *asyncRun) {
+	defer run.cancel()
+
+	var value any
+	var err error
+	var pending bool
+
+	// the node's dependencies are tracked, while the nodes and cleanups created by the computation
+	// are owned by the run, so that the node's own owner is only ever modified within its runtime
+	run.runtime.Run(func() {
+		run.tracker.RunWithComputation(a.Computed, func() {
+			run.tracker.RunWithOwner(run.owner, func() {
+				defer func() {
+					if r := recover(); r != nil {
+						if r == ErrPending {
+							pending = true
+							return
+						}
+
+						err = fmt.Errorf("async computed panicked: %v", r)
+					}
+				}()
+
+				value, err = a.compute(run.ctx)
+			})
+		})
+	})
+
+	a.runtime.Dispatch(func() {
+		// read a pending node, stay pending until it resolves and triggers a new run
+		if !pending {
+			a.resolve(run, AsyncResult{value, err})
+		}
+
+		a.finish(run)
+	})
+}
+
+// stopCurrent stops the run in flight, if any, its result is discarded.
+func (a *AsyncComputed) stopCurrent() {
+	a.mu.Lock()
+	current := a.current
+	a.current = nil
+	a.mu.Unlock()
+
+	if current != nil {
+		a.stop(current)
+	}
+}
+
+// stop cancels the run and prevents it from tracking further dependencies.
+func (a *AsyncComputed) stop(run *asyncRun) {
+	run.cancel()
+	run.tracker.Detach()
+
+	a.mu.Lock()
+	run.stopped = true
+	done := run.finished
+	a.mu.Unlock()
+
+	if done {
+		a.release(run)
+	}
+}
+
+// finish marks the computation of the run as returned.
+func (a *AsyncComputed) finish(run *asyncRun) {
+	a.mu.Lock()
+	run.finished = true
+	done := run.stopped
+	a.mu.Unlock()
+
+	if done {
+		a.release(run)
+	}
+}
+
+// release disposes the cleanups and nodes of a run that is both stopped and finished,
+// and keeps its runtime for the next runs.
+func (a *AsyncComputed) release(run *asyncRun) {
+	run.runtime.Run(run.owner.Dispose)
+
+	a.mu.Lock()
+	if a.idle == nil {
+		a.idle = run.runtime
+	}
+	a.mu.Unlock()
+}
+
+func (a *AsyncComputed) resolve(run *asyncRun, result AsyncResult) {
+	a.mu.Lock()
+	if run != a.current || a.HasFlag(FlagDisposed) {
+		a.mu.Unlock()
+		return
+	}
+
+	var value any = result
+
+	a.Signal.mu.Lock()
+	a.pendingValue = &value
+	a.Signal.mu.Unlock()
+
+	a.RemoveFlag(FlagPending)
+	a.resolved = true
+	a.mu.Unlock()
+
+	// update the boundary and the subscribers in a single flush
+	a.runtime.NewBatch(func() {
+		a.syncBoundary()
+
+		// always notify, subscribers have to rerun once the node is no longer pending
+		a.notify()
+	})
+}
+
+func (a *AsyncComputed) syncBoundary() {
+	if a.boundary != nil {
+		a.boundary.sync(a)
+	}
+}
+
+func asyncPredicate(a, b any) bool {
+	ra, rb := a.(AsyncResult), b.(AsyncResult)
+	return DefaultPredicate(ra.Err, rb.Err) && DefaultPredicate(ra.Value, rb.Value)
+}
@@ -1,26 +1,25 @@
 package internal
 
+import "sync/atomic"
+
 type Batcher struct {
 	// each nested batch increases the depth by 1
 	// if depth > 0, updates are queued until the outermost batch is complete
-	depth int
+	depth atomic.Int64
 }
 
 func NewBatcher() *Batcher {
-	return &Batcher{
-		depth: 0,
-	}
+	return &Batcher{}
 }
 
 func (b *Batcher) IsBatching() bool {
-	return b.depth > 0
+	return b.depth.Load() > 0
 }
 
 func (b *Batcher) Batch(fn, onComplete func()) {
-	b.depth++
+	b.depth.Add(1)
 	defer func() {
-		b.depth--
-		if b.depth == 0 && onComplete != nil {
+		if b.depth.Add(-1) == 0 && onComplete != nil {
 			onComplete()
 		}
 	}()
@@ -29,7 +28,39 @@ func (b *Batcher) Batch(fn, onComplete func()) {
 }
 
 func (r *Runtime) NewBatch(fn func()) {
-	r.batcher.Batch(fn, func() {
-		r.Schedule(true)
+	r.Run(func() {
+		r.batcher.Batch(fn, func() {
+			r.flushDeferred()
+			r.Schedule(true)
+		})
 	})
 }
+
+// deferWrite holds back a write to a signal of another runtime until the current batch completes.
+func (r *Runtime) deferWrite(owner *Runtime, write func()) {
+	r.deferredMu.Lock()
+	defer r.deferredMu.Unlock()
+
+	if r.deferred == nil {
+		r.deferred = make(map[*Runtime][]func())
+	}
+	r.deferred[owner] = append(r.deferred[owner], write)
+}
+
+// flushDeferred applies the deferred writes, in a single batch for each runtime they belong to.
+func (r *Runtime) flushDeferred() {
+	r.deferredMu.Lock()
+	deferred := r.deferred
+	r.deferred = nil
+	r.deferredMu.Unlock()
+
+	for owner, writes := range deferred {
+		owner.Dispatch(func() {
+			owner.NewBatch(func() {
+				for _, write := range writes {
+					write()
+				}
+			})
+		})
+	}
+}
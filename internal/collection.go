@@ -0,0 +1,101 @@
+package internal
+
+import "sync"
+
+// Collection tracks the reads of a keyed collection, like a map or a set: each key has its own signal,
+// kept while the key is tracked, and the keys themselves are tracked by a signal written when keys are added or removed.
+type Collection struct {
+	// the runtime the collection was created in, the signals of its keys belong to it
+	runtime *Runtime
+
+	mu        sync.Mutex
+	keys      map[any]*Signal
+	structure *Signal
+}
+
+func (r *Runtime) NewCollection() *Collection {
+	return &Collection{
+		runtime:   r,
+		keys:      make(map[any]*Signal),
+		structure: r.NewSignal(uint64(0)),
+	}
+}
+
+// TrackKey tracks the entry of the given key, whether it exists or not.
+// The signal of the key is created as needed, and dropped once no computation depends on it.
+func (c *Collection) TrackKey(key any) {
+	comp := GetRuntime().TrackingComputation()
+	if comp == nil {
+		return
+	}
+
+	// subscribe before releasing the keys, so that the signal is not dropped in between
+	c.mu.Lock()
+	s, ok := c.keys[key]
+	if !ok {
+		s = c.runtime.NewSignal(uint64(0))
+		c.keys[key] = s
+	}
+	s.Read()
+	c.mu.Unlock()
+
+	comp.OnCleanup(func() { c.drop(key, comp) })
+}
+
+// drop removes the signal of the key once it has no subscribers other than comp, which is being cleaned up.
+func (c *Collection) drop(key any, comp *Computed) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.keys[key]
+	if !ok {
+		return
+	}
+
+	for sub := range s.Subs() {
+		if sub != comp {
+			return
+		}
+	}
+
+	delete(c.keys, key)
+}
+
+// TrackedKeys returns the number of keys with a signal.
+func (c *Collection) TrackedKeys() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.keys)
+}
+
+// TrackStructure tracks the keys of the collection.
+func (c *Collection) TrackStructure() {
+	c.structure.Read()
+}
+
+// Notify updates the dependents of the given keys, and of the keys of the collection if its structure changed.
+// Every dependent is updated once, even if it tracks several of them.
+func (c *Collection) Notify(keys []any, structure bool) {
+	c.mu.Lock()
+	signals := make([]*Signal, 0, len(keys)+1)
+	for _, key := range keys {
+		if s, ok := c.keys[key]; ok {
+			signals = append(signals, s)
+		}
+	}
+	c.mu.Unlock()
+
+	if structure {
+		signals = append(signals, c.structure)
+	}
+
+	if len(signals) == 0 {
+		return
+	}
+
+	GetRuntime().NewBatch(func() {
+		for _, s := range signals {
+			s.Update(func(v any) any { return v.(uint64) + 1 })
+		}
+	})
+}
@@ -12,20 +12,62 @@ type Computed struct {
 	mu          sync.RWMutex
 	initialized bool
 
+	// resolved nodes computed a value, others still hold their initial one
+	resolved bool
+
+	// eager nodes are updated as soon as their dependencies change,
+	// others only once they are read
+	eager bool
+
+	// paused nodes keep their marks without being updated, until resumed
+	paused bool
+
 	// called whenever the nodes has to recompute its value
 	fn func()
 
+	// called before the node's dependencies are cleared to recompute it,
+	// stopping computations of the node that might still be running and tracking dependencies
+	detach func()
+
 	depsHead *DependencyLink
 
 	compute func(*Computed) any
+
+	name string
+}
+
+type ComputedOptions struct {
+	Initial   any                 // value held until the first computation resolves
+	Predicate func(a, b any) bool // nil for the default predicate
+	Name      string              // for debugging purposes
+}
+
+func (r *Runtime) NewComputed(compute func(*Computed) any, options ...ComputedOptions) *Computed {
+	c := r.newComputed(compute)
+
+	if len(options) > 0 {
+		opts := options[0]
+
+		c.Signal.value = opts.Initial
+		c.name = opts.Name
+		if opts.Predicate != nil {
+			c.SetPredicate(opts.Predicate)
+		}
+	}
+
+	r.Run(func() { r.recompute(c) })
+
+	return c
 }
 
-func (r *Runtime) NewComputed(compute func(*Computed) any) *Computed {
+// newComputed creates a computed node without running it.
+func (r *Runtime) newComputed(compute func(*Computed) any) *Computed {
 	c := &Computed{
 		Owner:   r.NewOwner(),
 		Signal:  r.NewSignal(nil),
 		compute: compute,
 	}
+	c.Signal.computed = c
 
 	c.mu.Lock()
 	c.fn = c.run
@@ -33,17 +75,52 @@ func (r *Runtime) NewComputed(compute func(*Computed) any) *Computed {
 
 	c.OnDispose(func() {
 		if c.depsHead != nil {
+			r.mu.Lock()
 			r.heap.Remove(c)
+			r.mu.Unlock()
 			c.ClearDeps()
 		}
 		c.SetFlags(FlagDisposed)
 	})
 
-	r.recompute(c)
-
 	return c
 }
 
+// Write overrides the node's value, until one of its dependencies changes and it is recomputed.
+func (c *Computed) Write(v any) {
+	c.Update(func(any) any { return v })
+}
+
+// Update is like Write, with the result of fn called with the current value. See Signal.Update.
+func (c *Computed) Update(fn func(any) any) {
+	c.refresh()
+	c.Signal.Update(func(v any) any {
+		// dependency changes that have not been pulled yet are overridden as well
+		c.RemoveFlag(FlagCheck | FlagDirty)
+		return fn(v)
+	})
+}
+
+// Read the node's value, recomputing it first if one of its dependencies changed since it last ran.
+func (c *Computed) Read() any {
+	c.refresh()
+	return c.Signal.Read()
+}
+
+// Peek is like Read, without tracking the read.
+func (c *Computed) Peek() any {
+	c.refresh()
+	return c.Value()
+}
+
+// refresh recomputes the node if one of its dependencies changed since it last ran.
+func (c *Computed) refresh() {
+	if c.HasFlag(FlagCheck | FlagDirty) {
+		r := c.Signal.runtime
+		r.Run(func() { r.update(c) })
+	}
+}
+
 func (c *Computed) run() {
 	c.mu.Lock()
 	shouldCleanup := c.initialized
@@ -54,11 +131,21 @@ func (c *Computed) run() {
 		c.Cleanup()
 	}
 
-	value := c.compute(c)
+	var value any
+	if catchPending(func() { value = c.compute(c) }) {
+		// keep the previous value until the pending dependency resolves
+		c.AddFlag(FlagPending)
+		return
+	}
+	c.RemoveFlag(FlagPending)
 
 	c.Signal.mu.Lock()
 	c.pendingValue = &value
 	c.Signal.mu.Unlock()
+
+	c.mu.Lock()
+	c.resolved = true
+	c.mu.Unlock()
 }
 
 // Link creates a bidirectional dependency link between this node (subcriber) and the given node (dependency).
@@ -106,12 +193,34 @@ func (c *Computed) Deps() iter.Seq[*Signal] {
 	}
 }
 
+func (c *Computed) Name() string {
+	return c.name
+}
+
+func (c *Computed) isResolved() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.resolved
+}
+
+func (c *Computed) isPaused() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.paused
+}
+
 func (c *Computed) getFn() func() {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return c.fn
 }
 
+func (c *Computed) getDetach() func() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.detach
+}
+
 // ClearDeps removes all dependencies
 func (c *Computed) ClearDeps() {
 	c.mu.Lock()
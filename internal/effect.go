@@ -11,19 +11,33 @@ type Effect struct {
 	*Computed
 
 	typ EffectType
+
+	// the suspense boundary the effect is in, if any
+	boundary *Suspense
+
+	// for effects split in a compute and an apply phase, see NewEffectWith
+	apply   func(next, prev any) func()
+	applied bool
+	prev    any    // value of the last apply
+	cleanup func() // returned by the last apply
 }
 
 func (r *Runtime) NewEffect(typ EffectType, effect func()) *Effect {
-	var e *Effect
-
-	e = &Effect{
-		Computed: r.NewComputed(func(node *Computed) any {
+	e := &Effect{
+		Computed: r.newComputed(func(node *Computed) any {
 			effect()
 			return nil
 		}),
 
 		typ: typ,
 	}
+	e.eager = true
+	e.boundary = e.Owner.Boundary()
+
+	// the first run is synchronous, unless held back by a suspense boundary
+	if !e.hold() {
+		r.Run(func() { r.recompute(e.Computed) })
+	}
 
 	e.mu.Lock()
 	e.fn = e.run
@@ -32,7 +46,64 @@ func (r *Runtime) NewEffect(typ EffectType, effect func()) *Effect {
 	return e
 }
 
+// NewEffectWith creates an effect split in two phases: compute tracks the effect's dependencies and runs
+// when they change, then apply is called untracked with the computed value and the previous one, along with user effects.
+// Apply is skipped when the computed value did not change, the function it returns is called before the next apply.
+func (r *Runtime) NewEffectWith(typ EffectType, compute func(prev any) any, apply func(next, prev any) func()) *Effect {
+	e := &Effect{
+		Computed: r.newComputed(func(c *Computed) any {
+			return compute(c.Value())
+		}),
+
+		typ:   typ,
+		apply: apply,
+	}
+	e.eager = true
+	e.boundary = e.Owner.Boundary()
+
+	e.mu.Lock()
+	e.fn = e.runWith
+	e.mu.Unlock()
+
+	e.OnDispose(func() {
+		e.mu.Lock()
+		cleanup := e.cleanup
+		e.cleanup = nil
+		e.mu.Unlock()
+
+		if cleanup != nil {
+			cleanup()
+		}
+	})
+
+	r.Run(func() { r.recompute(e.Computed) })
+
+	return e
+}
+
+// NewEffectOn creates an effect only depending on the nodes deps reads. Body is called untracked with the value
+// deps returns and the one it returned on the previous run, and is skipped on the first run when deferred.
+func (r *Runtime) NewEffectOn(typ EffectType, deps func() any, body func(next, prev any), deferred bool) *Effect {
+	var prev any
+	skip := deferred
+
+	return r.NewEffect(typ, func() {
+		next := deps()
+
+		if !skip {
+			r.tracker.RunUntracked(func() { body(next, prev) })
+		}
+
+		skip = false
+		prev = next
+	})
+}
+
 func (e *Effect) run() {
+	if e.hold() {
+		return
+	}
+
 	r := GetRuntime()
 
 	r.effectQueue.Enqueue(e.Type(), func() {
@@ -40,12 +111,118 @@ func (e *Effect) run() {
 			return
 		}
 
+		// paused after being scheduled, run once resumed
+		if e.isPaused() {
+			e.AddFlag(FlagDirty)
+			return
+		}
+
 		r.tracker.RunWithComputation(e.Computed, e.Computed.run)
 	})
 
 	r.Schedule(false)
 }
 
+// runWith computes the effect's value, tracking its dependencies, then schedules the apply phase with it.
+func (e *Effect) runWith() {
+	if e.hold() {
+		return
+	}
+
+	e.Computed.run()
+	if e.HasFlag(FlagPending) {
+		return
+	}
+
+	r := GetRuntime()
+	next := e.Value()
+
+	r.effectQueue.Enqueue(e.Type(), func() {
+		if e.HasFlag(FlagDisposed) {
+			return
+		}
+
+		// paused after being scheduled, run once resumed
+		if e.isPaused() {
+			e.AddFlag(FlagDirty)
+			return
+		}
+
+		e.applyValue(r, next)
+	})
+
+	r.Schedule(false)
+}
+
+func (e *Effect) applyValue(r *Runtime, next any) {
+	e.Signal.mu.RLock()
+	predicate := e.predicate
+	e.Signal.mu.RUnlock()
+
+	e.mu.Lock()
+	prev, cleanup := e.prev, e.cleanup
+	if e.applied && predicate(prev, next) {
+		e.mu.Unlock()
+		return
+	}
+
+	e.applied = true
+	e.prev = next
+	e.cleanup = nil
+	e.mu.Unlock()
+
+	if cleanup != nil {
+		cleanup()
+	}
+
+	r.tracker.RunWithOwner(e.Owner, func() {
+		r.tracker.RunUntracked(func() {
+			cleanup = e.apply(next, prev)
+		})
+	})
+
+	e.mu.Lock()
+	e.cleanup = cleanup
+	e.mu.Unlock()
+}
+
+// Pause keeps the effect from running, without unsubscribing it from its dependencies.
+func (e *Effect) Pause() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.paused = true
+}
+
+// Resume lets a paused effect run again. It is run right away if one of its dependencies changed while it was paused.
+func (e *Effect) Resume() {
+	e.mu.Lock()
+	e.paused = false
+	e.mu.Unlock()
+
+	if !e.HasFlag(FlagCheck | FlagDirty) {
+		return
+	}
+
+	r := e.Signal.runtime
+	r.Dispatch(func() {
+		r.mu.Lock()
+		r.heap.Insert(e.Computed)
+		r.mu.Unlock()
+
+		r.Schedule(true)
+	})
+}
+
+// hold reports whether the effect is held back by its suspense boundary.
+// Held effects are scheduled again once every pending node of the boundary resolves.
+func (e *Effect) hold() bool {
+	if e.Type() != EffectRender || e.boundary == nil {
+		return false
+	}
+
+	return e.boundary.hold(e)
+}
+
 func (e *Effect) Type() EffectType {
 	e.mu.Lock()
 	defer e.mu.Unlock()
@@ -4,17 +4,21 @@ import (
 	"iter"
 )
 
+// initial number of heights the heap has room for, it grows as deeper nodes are inserted
+const minHeapSize = 64
+
 type PriorityHeap struct {
 	min int
 	max int
 
-	nodes []*heapNode // [height]head
+	nodes []*heapNode // [height]head, grown on demand
 
 	loopkup map[*Computed]*heapNode // for O(1) removal
 }
 
 type heapNode struct {
-	node *Computed
+	node   *Computed
+	height int // height the node was inserted at, it may change while in the heap
 
 	next *heapNode
 	prev *heapNode
@@ -24,7 +28,7 @@ func NewHeap() *PriorityHeap {
 	return &PriorityHeap{
 		min:     0,
 		max:     0,
-		nodes:   make([]*heapNode, 2000),
+		nodes:   make([]*heapNode, minHeapSize),
 		loopkup: make(map[*Computed]*heapNode),
 	}
 }
@@ -35,10 +39,14 @@ func (h *PriorityHeap) Insert(node *Computed) {
 	}
 	node.AddFlag(FlagInHeap)
 
-	entry := &heapNode{node: node}
+	height := node.GetHeight()
+
+	entry := &heapNode{node: node, height: height}
 	h.loopkup[node] = entry
 
-	height := node.GetHeight()
+	if height >= len(h.nodes) {
+		h.grow(height + 1)
+	}
 
 	if h.nodes[height] == nil {
 		h.nodes[height] = entry
@@ -57,6 +65,11 @@ func (h *PriorityHeap) Insert(node *Computed) {
 	if height > h.max {
 		h.max = height
 	}
+
+	// inserted below the height currently being drained, go back to it
+	if height < h.min {
+		h.min = height
+	}
 }
 
 func (h *PriorityHeap) InsertAll(nodes iter.Seq[*Computed]) {
@@ -66,18 +79,15 @@ func (h *PriorityHeap) InsertAll(nodes iter.Seq[*Computed]) {
 }
 
 func (h *PriorityHeap) Remove(node *Computed) {
-	if !node.HasFlag(FlagInHeap) {
-		return
-	}
-	node.RemoveFlag(FlagInHeap)
-
+	// looked up rather than relying on FlagInHeap, which disposal clears
 	entry, ok := h.loopkup[node]
 	if !ok {
 		return
 	}
 	delete(h.loopkup, node)
+	node.RemoveFlag(FlagInHeap)
 
-	height := entry.node.GetHeight()
+	height := entry.height
 
 	// single node
 	if entry.prev == entry {
@@ -117,5 +127,22 @@ func (h *PriorityHeap) Drain(process func(*Computed)) {
 		}
 	}
 
+	h.shrink(h.max + 1)
 	h.max = 0
 }
+
+// grow makes room for at least size heights.
+func (h *PriorityHeap) grow(size int) {
+	nodes := make([]*heapNode, max(size, 2*len(h.nodes)))
+	copy(nodes, h.nodes)
+	h.nodes = nodes
+}
+
+// shrink gives back the room of an empty heap when much less than its size was used.
+func (h *PriorityHeap) shrink(used int) {
+	if len(h.nodes) <= minHeapSize || used > len(h.nodes)/4 {
+		return
+	}
+
+	h.nodes = make([]*heapNode, max(minHeapSize, len(h.nodes)/2))
+}
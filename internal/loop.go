@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrLoopStopped is returned when dispatching to a loop that has been stopped.
+var ErrLoopStopped = errors.New("loop is stopped")
+
+// Loop owns a runtime and runs the functions dispatched to it one after the other on a dedicated goroutine,
+// each of them in its own batch.
+type Loop struct {
+	runtime *Runtime
+
+	mu      sync.Mutex
+	queue   []loopTask
+	stopped bool
+
+	wake chan struct{}
+	done chan struct{} // closed once the loop's goroutine has exited
+
+	gid atomic.Int64 // id of the loop's goroutine
+}
+
+type loopTask struct {
+	fn     func()
+	result chan error // nil if no one waits for the task
+}
+
+func NewLoop() *Loop {
+	l := &Loop{
+		runtime: NewRuntime(),
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	// writes to the loop's signals and async results from other goroutines are run on the loop as well,
+	// so that effects never run outside of it
+	l.runtime.dispatch = func(fn func()) { l.Dispatch(fn) }
+
+	go l.loop()
+
+	return l
+}
+
+func (l *Loop) Runtime() *Runtime {
+	return l.runtime
+}
+
+// Dispatch queues fn to be run on the loop. It reports whether fn was queued, which it is not once the loop is stopped.
+func (l *Loop) Dispatch(fn func()) bool {
+	return l.enqueue(loopTask{fn: fn})
+}
+
+func (l *Loop) enqueue(task loopTask) bool {
+	l.mu.Lock()
+	if l.stopped {
+		l.mu.Unlock()
+		return false
+	}
+
+	l.queue = append(l.queue, task)
+	l.mu.Unlock()
+
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// DispatchSync runs fn on the loop and waits for it to return, along with the update it triggered.
+// A panic in fn is returned as an error instead of stopping the loop.
+func (l *Loop) DispatchSync(fn func()) error {
+	// already on the loop, waiting for the queue would never return
+	if l.gid.Load() == getGID() {
+		return call(fn)
+	}
+
+	result := make(chan error, 1)
+	if !l.enqueue(loopTask{fn, result}) {
+		return ErrLoopStopped
+	}
+
+	return <-result
+}
+
+// Stop prevents new functions from being dispatched, waits for the queued ones to run,
+// and disposes every owner created without a parent on the loop.
+func (l *Loop) Stop() {
+	l.mu.Lock()
+	l.stopped = true
+	l.mu.Unlock()
+
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+
+	// stopped from the loop itself, it exits once the current function returns
+	if l.gid.Load() == getGID() {
+		return
+	}
+
+	<-l.done
+}
+
+func (l *Loop) loop() {
+	l.gid.Store(getGID())
+	defer close(l.done)
+
+	for {
+		task, ok := l.next()
+		if !ok {
+			break
+		}
+
+		if task.result == nil {
+			l.runtime.NewBatch(task.fn)
+			continue
+		}
+
+		var err error
+		l.runtime.NewBatch(func() { err = call(task.fn) })
+		task.result <- err
+	}
+
+	l.runtime.Run(l.runtime.Dispose)
+}
+
+// next waits for the next queued function. It returns false once the loop is stopped and its queue is empty.
+func (l *Loop) next() (loopTask, bool) {
+	for {
+		l.mu.Lock()
+		if len(l.queue) > 0 {
+			task := l.queue[0]
+			l.queue[0] = loopTask{}
+			l.queue = l.queue[1:]
+			l.mu.Unlock()
+
+			return task, true
+		}
+
+		stopped := l.stopped
+		l.mu.Unlock()
+
+		if stopped {
+			return loopTask{}, false
+		}
+
+		<-l.wake
+	}
+}
+
+// call runs fn, returning a panic as an error.
+func call(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("dispatched function panicked: %v", r)
+		}
+	}()
+
+	fn()
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// reentrantMutex is a mutex that can be locked again by the goroutine already holding it.
+// Each Lock must be paired with an Unlock, the mutex is released once all of them are.
+type reentrantMutex struct {
+	mu sync.Mutex
+
+	holder atomic.Int64 // id of the goroutine holding the mutex, 0 if none
+	depth  int
+}
+
+func (m *reentrantMutex) Lock() {
+	m.lock(getGID())
+}
+
+// lock is Lock for the goroutine of the given id, the current one.
+func (m *reentrantMutex) lock(gid int64) {
+	if m.holder.Load() == gid {
+		m.depth++
+		return
+	}
+
+	m.mu.Lock()
+	m.holder.Store(gid)
+	m.depth = 1
+}
+
+func (m *reentrantMutex) Unlock() {
+	m.depth--
+	if m.depth == 0 {
+		m.holder.Store(0)
+		m.mu.Unlock()
+	}
+}
+
+// TryLock locks the mutex if it is free or already held by the current goroutine, and reports whether it did.
+func (m *reentrantMutex) TryLock() bool {
+	return m.tryLock(getGID())
+}
+
+func (m *reentrantMutex) tryLock(gid int64) bool {
+	if m.holder.Load() == gid {
+		m.depth++
+		return true
+	}
+
+	if !m.mu.TryLock() {
+		return false
+	}
+	m.holder.Store(gid)
+	m.depth = 1
+	return true
+}
+
+// Held reports whether the mutex is held by the current goroutine.
+func (m *reentrantMutex) Held() bool {
+	return m.held(getGID())
+}
+
+func (m *reentrantMutex) held(gid int64) bool {
+	return m.holder.Load() == gid
+}
@@ -10,6 +10,7 @@ const (
 	FlagDirty    NodeFlags = 1 << 1 // node is dirty and needs to be recomputed
 	FlagInHeap   NodeFlags = 1 << 2 // node is currently in heap for update scheduling
 	FlagDisposed NodeFlags = 1 << 3 // node has been disposed
+	FlagPending  NodeFlags = 1 << 4 // node is waiting for an async value to resolve
 )
 
 type ReactiveNode struct {
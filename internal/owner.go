@@ -14,6 +14,9 @@ type Owner struct {
 	// the context values of this owner
 	context map[uint64]any
 
+	// set if this owner is a suspense boundary
+	suspense *Suspense
+
 	parent       *Owner
 	prevSibling  *Owner
 	nextSibling  *Owner
@@ -28,6 +31,8 @@ func (r *Runtime) NewOwner() *Owner {
 
 	if parent := r.CurrentOwner(); parent != nil {
 		parent.AddChild(o)
+	} else {
+		r.addRoot(o)
 	}
 
 	return o
@@ -35,7 +40,9 @@ func (r *Runtime) NewOwner() *Owner {
 
 func (o *Owner) Run(fn func() error) (err error) {
 	r := GetRuntime()
-	r.tracker.RunWithOwner(o, func() { err = fn() })
+	r.Run(func() {
+		r.tracker.RunWithOwner(o, func() { err = fn() })
+	})
 
 	return err
 }
@@ -52,6 +59,20 @@ func (parent *Owner) AddChild(child *Owner) {
 	parent.childrenHead = child
 }
 
+// removeChild unlinks the child from its siblings.
+// The child keeps its own sibling pointers, so that iterating over the children while disposing them carries on.
+func (parent *Owner) removeChild(child *Owner) {
+	if child.prevSibling != nil {
+		child.prevSibling.nextSibling = child.nextSibling
+	} else if parent.childrenHead == child {
+		parent.childrenHead = child.nextSibling
+	}
+
+	if child.nextSibling != nil {
+		child.nextSibling.prevSibling = child.prevSibling
+	}
+}
+
 func (n *Owner) Children() iter.Seq[*Owner] {
 	return func(yield func(*Owner) bool) {
 		child := n.childrenHead
@@ -66,6 +87,17 @@ func (n *Owner) Children() iter.Seq[*Owner] {
 	}
 }
 
+// Boundary returns the closest suspense boundary this owner is in, or nil if none.
+func (n *Owner) Boundary() *Suspense {
+	for owner := n; owner != nil; owner = owner.parent {
+		if owner.suspense != nil {
+			return owner.suspense
+		}
+	}
+
+	return nil
+}
+
 func (n *Owner) Cleanup() {
 	n.DisposeChildren()
 
@@ -76,6 +108,7 @@ func (n *Owner) Cleanup() {
 }
 
 func (n *Owner) Dispose() {
+	defer n.detach()
 	defer n.recover()
 
 	n.Cleanup()
@@ -86,6 +119,14 @@ func (n *Owner) Dispose() {
 	n.disposeListeners = nil
 }
 
+// detach removes a disposed owner from its parent's children.
+func (n *Owner) detach() {
+	if n.parent != nil {
+		n.parent.removeChild(n)
+		n.parent = nil
+	}
+}
+
 func (n *Owner) DisposeChildren() {
 	for child := range n.Children() {
 		child.Dispose()
@@ -0,0 +1,34 @@
+package internal
+
+import "errors"
+
+// ErrPending is used to interrupt a computation reading a node that has not resolved yet.
+var ErrPending = errors.New("value is pending")
+
+// IsPending runs fn and reports whether it read a pending node.
+func (r *Runtime) IsPending(fn func()) (pending bool) {
+	r.Run(func() {
+		r.tracker.RunProbing(func() {
+			pending = catchPending(fn)
+		})
+	})
+
+	return pending
+}
+
+// catchPending runs fn and reports whether it was interrupted by a pending read.
+func catchPending(fn func()) (pending bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != ErrPending {
+				panic(r)
+			}
+
+			pending = true
+		}
+	}()
+
+	fn()
+
+	return false
+}
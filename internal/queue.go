@@ -1,6 +1,9 @@
 package internal
 
+import "sync"
+
 type EffectQueue struct {
+	mu      sync.Mutex
 	effects map[EffectType][]func()
 }
 
@@ -9,16 +12,20 @@ func NewEffectQueue() *EffectQueue {
 	effects[EffectRender] = make([]func(), 0)
 	effects[EffectUser] = make([]func(), 0)
 
-	return &EffectQueue{effects}
+	return &EffectQueue{effects: effects}
 }
 
 func (q *EffectQueue) Enqueue(typ EffectType, fn func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 	q.effects[typ] = append(q.effects[typ], fn)
 }
 
 func (q *EffectQueue) RunEffects(typ EffectType) {
+	q.mu.Lock()
 	effects := q.effects[typ]
-	q.ClearEffects(typ)
+	q.effects[typ] = make([]func(), 0)
+	q.mu.Unlock()
 
 	for _, effect := range effects {
 		effect()
@@ -26,10 +33,13 @@ func (q *EffectQueue) RunEffects(typ EffectType) {
 }
 
 func (q *EffectQueue) ClearEffects(typ EffectType) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 	q.effects[typ] = q.effects[typ][:0]
 }
 
 type NodeQueue struct {
+	mu      sync.Mutex
 	signals []*Signal
 }
 
@@ -40,10 +50,21 @@ func NewNodeQueue() *NodeQueue {
 }
 
 func (q *NodeQueue) Enqueue(node *Signal) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 	q.signals = append(q.signals, node)
 }
 
+func (q *NodeQueue) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.signals = q.signals[:0]
+}
+
 func (q *NodeQueue) Commit() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
 	for _, node := range q.signals {
 		node.Commit()
 	}
@@ -52,6 +73,7 @@ func (q *NodeQueue) Commit() {
 }
 
 type SettledQueue struct {
+	mu        sync.Mutex
 	callbacks []func()
 }
 
@@ -62,14 +84,24 @@ func NewSettledQueue() *SettledQueue {
 }
 
 func (q *SettledQueue) Enqueue(fn func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 	q.callbacks = append(q.callbacks, fn)
 }
 
 func (q *SettledQueue) Run() {
+	q.mu.Lock()
 	callbacks := q.callbacks
-	q.callbacks = q.callbacks[:0]
+	q.callbacks = make([]func(), 0)
+	q.mu.Unlock()
 
 	for _, cb := range callbacks {
 		cb()
 	}
 }
+
+func (q *SettledQueue) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.callbacks = make([]func(), 0)
+}
@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// default runtimes, by goroutine id
+var runtimes sync.Map
+
+var (
+	// number of runtimes in the registry
+	registered atomic.Int64
+
+	// registry size from which dead goroutines' runtimes are reclaimed
+	reclaimThreshold atomic.Int64
+
+	reclaimMu sync.Mutex
+)
+
+const minReclaimThreshold = 64
+
+func init() {
+	reclaimThreshold.Store(minReclaimThreshold)
+}
+
+func GetRuntime() *Runtime {
+	gid := getGID()
+
+	if bound.Load() > 0 {
+		if r, ok := bindings.Load(gid); ok {
+			return r.(*Runtime)
+		}
+	}
+
+	if r, ok := runtimes.Load(gid); ok {
+		return r.(*Runtime)
+	}
+
+	r := NewRuntime()
+	runtimes.Store(gid, r)
+
+	// a single goroutine reclaims at a time, the others registering meanwhile don't wait for it
+	if registered.Add(1) >= reclaimThreshold.Load() && reclaimMu.TryLock() {
+		defer reclaimMu.Unlock()
+
+		// the registry may have been reclaimed while the runtime was registered
+		if registered.Load() >= reclaimThreshold.Load() {
+			reclaim()
+		}
+	}
+
+	return r
+}
+
+// isDefaultRuntime reports whether r is the default runtime of the goroutine of the given id.
+func isDefaultRuntime(gid int64, r *Runtime) bool {
+	d, ok := runtimes.Load(gid)
+	return ok && d == r
+}
+
+// DisposeRuntime disposes the current goroutine's default runtime and removes it from the registry.
+// A new runtime is created the next time the goroutine uses one.
+func DisposeRuntime() {
+	if r, ok := runtimes.LoadAndDelete(getGID()); ok {
+		registered.Add(-1)
+		r.(*Runtime).Dispose()
+	}
+}
+
+// Reclaim removes the runtimes of goroutines that have exited from the registry.
+// Their nodes remain usable, they simply are no longer reachable through the registry.
+func Reclaim() {
+	reclaimMu.Lock()
+	defer reclaimMu.Unlock()
+
+	reclaim()
+}
+
+// reclaim is Reclaim, reclaimMu being held.
+func reclaim() {
+	// only consider runtimes registered before listing the live goroutines,
+	// since goroutine ids are never reused, any of them missing from the list has exited
+	var gids []int64
+	runtimes.Range(func(key, _ any) bool {
+		gids = append(gids, key.(int64))
+		return true
+	})
+
+	live := liveGoroutines()
+	for _, gid := range gids {
+		if _, ok := live[gid]; !ok {
+			if _, ok := runtimes.LoadAndDelete(gid); ok {
+				registered.Add(-1)
+			}
+		}
+	}
+
+	reclaimThreshold.Store(max(2*registered.Load(), minReclaimThreshold))
+}
+
+// RuntimeCount returns the number of runtimes in the registry.
+func RuntimeCount() int {
+	return int(registered.Load())
+}
+
+// liveGoroutines returns the ids of all the goroutines currently running.
+func liveGoroutines() map[int64]struct{} {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+
+		buf = make([]byte, 2*len(buf))
+	}
+
+	live := make(map[int64]struct{})
+	prefix := []byte("goroutine ")
+
+	for line := range bytes.Lines(buf) {
+		if !bytes.HasPrefix(line, prefix) {
+			continue
+		}
+
+		fields := bytes.Fields(line[len(prefix):])
+		if len(fields) == 0 {
+			continue
+		}
+
+		if gid, err := strconv.ParseInt(string(fields[0]), 10, 64); err == nil {
+			live[gid] = struct{}{}
+		}
+	}
+
+	return live
+}
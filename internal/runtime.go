@@ -1,11 +1,34 @@
 package internal
 
 import (
+	"cmp"
+	"maps"
+	"slices"
 	"sync"
+	"sync/atomic"
 )
 
+// runtimes explicitly bound to a goroutine with Runtime.Run, by goroutine id
+var bindings sync.Map
+
+// number of goroutines in bindings, so that looking it up is skipped while there are none
+var bound atomic.Int64
+
 type Runtime struct {
-	mu sync.Mutex
+	// reentrant so that nodes can be written to while the runtime is being flushed
+	mu reentrantMutex
+
+	// held by the goroutine currently running within the runtime with Run
+	exec reentrantMutex
+
+	inboxMu sync.Mutex
+	inbox   []func() // work dispatched while another goroutine was running within the runtime
+
+	// forwards the work dispatched from outside the runtime, set for runtimes owned by a loop
+	dispatch func(fn func())
+
+	deferredMu sync.Mutex
+	deferred   map[*Runtime][]func() // writes to signals of other runtimes during a batch, by runtime
 
 	heap               *PriorityHeap
 	tracker            *Tracker
@@ -16,6 +39,10 @@ type Runtime struct {
 	settledQueue       *SettledQueue
 	userSettledQueue   *SettledQueue
 	renderSettledQueue *SettledQueue
+
+	rootsMu sync.Mutex
+	roots   map[*Owner]uint64 // owners created without a parent, disposed with the runtime, by creation order
+	rootSeq uint64
 }
 
 func NewRuntime() *Runtime {
@@ -29,7 +56,181 @@ func NewRuntime() *Runtime {
 		settledQueue:       NewSettledQueue(),
 		userSettledQueue:   NewSettledQueue(),
 		renderSettledQueue: NewSettledQueue(),
+		roots:              make(map[*Owner]uint64),
+	}
+}
+
+// Dispose disposes every root owner of the runtime, and drops any work it has pending.
+func (r *Runtime) Dispose() {
+	r.rootsMu.Lock()
+	roots := slices.SortedFunc(maps.Keys(r.roots), func(a, b *Owner) int {
+		return cmp.Compare(r.roots[b], r.roots[a]) // last created first, like owners' children
+	})
+	r.roots = make(map[*Owner]uint64)
+	r.rootsMu.Unlock()
+
+	for _, owner := range roots {
+		owner.Dispose()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.heap.Drain(func(*Computed) {})
+	r.nodeQueue.Clear()
+	r.effectQueue.ClearEffects(EffectRender)
+	r.effectQueue.ClearEffects(EffectUser)
+	r.settledQueue.Clear()
+	r.userSettledQueue.Clear()
+	r.renderSettledQueue.Clear()
+
+	r.inboxMu.Lock()
+	r.inbox = nil
+	r.inboxMu.Unlock()
+
+	r.deferredMu.Lock()
+	r.deferred = nil
+	r.deferredMu.Unlock()
+}
+
+func (r *Runtime) addRoot(owner *Owner) {
+	r.rootsMu.Lock()
+	r.rootSeq++
+	r.roots[owner] = r.rootSeq
+	r.rootsMu.Unlock()
+
+	owner.OnDispose(func() {
+		r.rootsMu.Lock()
+		delete(r.roots, owner)
+		r.rootsMu.Unlock()
+	})
+}
+
+// Run executes fn with r as the current goroutine's runtime,
+// so that reactive nodes read, written or created within fn are handled by r.
+// Runs are serialized, a single goroutine can run within a runtime at a time.
+func (r *Runtime) Run(fn func()) {
+	gid := getGID()
+
+	r.exec.lock(gid)
+	defer r.leave(gid)
+
+	r.bind(gid, fn)
+}
+
+// Dispatch executes fn within the runtime like Run, without waiting for other goroutines.
+// If another goroutine is running within the runtime, fn is queued and that goroutine runs it before leaving.
+// Runtimes owned by a loop always run dispatched work on the loop's goroutine.
+func (r *Runtime) Dispatch(fn func()) {
+	gid := getGID()
+
+	if r.exec.held(gid) {
+		r.bind(gid, fn)
+		return
+	}
+
+	if r.dispatch != nil {
+		r.dispatch(fn)
+		return
+	}
+
+	// no other goroutine is running within the runtime, run fn right away, after the work dispatched before it
+	if r.exec.tryLock(gid) {
+		defer r.leave(gid)
+
+		r.bind(gid, func() {
+			r.runInbox()
+			fn()
+		})
+		return
+	}
+
+	r.inboxMu.Lock()
+	r.inbox = append(r.inbox, fn)
+	r.inboxMu.Unlock()
+
+	if r.exec.tryLock(gid) {
+		r.leave(gid)
+	}
+}
+
+// leave releases the runtime, running the work dispatched to it beforehand when leaving the outermost Run.
+func (r *Runtime) leave(gid int64) {
+	if r.exec.depth > 1 {
+		r.exec.Unlock()
+		return
+	}
+
+	for {
+		if !r.inboxEmpty() {
+			r.bind(gid, r.runInbox)
+		}
+		r.exec.Unlock()
+
+		// work dispatched between the inbox being emptied and the runtime being released
+		// would not be picked up by anyone otherwise
+		if r.inboxEmpty() || !r.exec.tryLock(gid) {
+			return
+		}
+	}
+}
+
+// runInbox runs the work dispatched to the runtime, until there is none left.
+func (r *Runtime) runInbox() {
+	for fn := r.takeInbox(); fn != nil; fn = r.takeInbox() {
+		fn()
+	}
+}
+
+func (r *Runtime) inboxEmpty() bool {
+	r.inboxMu.Lock()
+	defer r.inboxMu.Unlock()
+	return len(r.inbox) == 0
+}
+
+func (r *Runtime) takeInbox() func() {
+	r.inboxMu.Lock()
+	defer r.inboxMu.Unlock()
+
+	if len(r.inbox) == 0 {
+		return nil
 	}
+
+	fn := r.inbox[0]
+	r.inbox[0] = nil
+	r.inbox = r.inbox[1:]
+	return fn
+}
+
+// bind executes fn with r as the runtime of the goroutine of the given id, the current one.
+func (r *Runtime) bind(gid int64, fn func()) {
+	var prev any
+	var ok bool
+	if bound.Load() > 0 {
+		prev, ok = bindings.Load(gid)
+	}
+
+	// already the goroutine's runtime, bound or by default, e.g. when dispatching from within the runtime
+	if ok && prev == r || !ok && isDefaultRuntime(gid, r) {
+		fn()
+		return
+	}
+
+	bindings.Store(gid, r)
+	if !ok {
+		bound.Add(1)
+	}
+
+	defer func() {
+		if ok {
+			bindings.Store(gid, prev)
+		} else {
+			bindings.Delete(gid)
+			bound.Add(-1)
+		}
+	}()
+
+	fn()
 }
 
 func (r *Runtime) Schedule(force bool) {
@@ -51,11 +252,16 @@ func (r *Runtime) Schedule(force bool) {
 }
 
 func (r *Runtime) Flush() {
+	// already flushing, pending updates will be picked up by the running flush
+	if r.scheduler.IsRunning() {
+		return
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	err := r.scheduler.Run(func() {
-		r.heap.Drain(r.recompute)
+		r.heap.Drain(r.update)
 
 		r.nodeQueue.Commit()
 
@@ -87,6 +293,10 @@ func (r *Runtime) CurrentComputation() *Computed {
 	return r.tracker.CurrentComputation()
 }
 
+func (r *Runtime) TrackingComputation() *Computed {
+	return r.tracker.TrackingComputation()
+}
+
 func (r *Runtime) OnCleanup(fn func()) {
 	owner := r.CurrentOwner()
 	if owner != nil {
@@ -108,19 +318,122 @@ func (r *Runtime) OnRenderSettled(fn func()) {
 
 func (r *Runtime) recompute(node *Computed) {
 	fn := node.getFn()
-	if fn == nil {
+	if fn == nil || node.HasFlag(FlagDisposed) {
 		return
 	}
 
 	oldValue := node.Value()
+	wasPending := node.HasFlag(FlagPending)
+	resolved := node.isResolved()
+
+	// computations still running must stop tracking first, they would link dependencies back otherwise
+	if detach := node.getDetach(); detach != nil {
+		detach()
+	}
 
 	node.DisposeChildren()
 	node.ClearDeps()
 	node.SetVersion(r.scheduler.Time())
 
+	// cleanups might have updated dependencies and marked the node again, it is being recomputed anyway
+	r.mu.Lock()
+	node.RemoveFlag(FlagCheck | FlagDirty)
+	r.heap.Remove(node)
+	r.mu.Unlock()
+
 	r.tracker.RunWithComputation(node, fn)
 
-	if !node.equals(oldValue) {
-		r.heap.InsertAll(node.Subs())
+	// values are only compared once the node resolved one, until then it holds its initial value, a mere placeholder
+	changed := node.HasFlag(FlagPending) != wasPending
+	if !changed && !resolved {
+		changed = node.isResolved()
+	} else if !changed {
+		node.Signal.mu.RLock()
+		changed = !node.equals(oldValue)
+		node.Signal.mu.RUnlock()
+	}
+
+	if changed {
+		r.markSubs(node.Signal, FlagDirty)
+	}
+}
+
+// update recomputes the node if it is dirty, or if it has to be checked and one of its dependencies changed.
+// Computed dependencies are updated first, so that the node only ever sees up to date values.
+// Dependencies are walked with an explicit stack rather than recursively, deep chains would otherwise
+// grow the goroutine's stack, which every lock of the runtime walks on platforms without goroutine ids.
+func (r *Runtime) update(node *Computed) {
+	type frame struct {
+		node *Computed
+		deps []*Signal // nil until the node is visited
+		next int       // index of the next dependency to update
+	}
+
+	stack := []frame{{node: node}}
+
+	for len(stack) > 0 {
+		f := &stack[len(stack)-1]
+		node := f.node
+
+		if f.deps == nil {
+			if node.HasFlag(FlagDisposed) || node.isPaused() {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+
+			f.deps = []*Signal{}
+			if node.HasFlag(FlagCheck) && !node.HasFlag(FlagDirty) {
+				f.deps = slices.Collect(node.Deps())
+			}
+		}
+
+		// update the next computed dependency, unless one of them already changed and marked the node dirty
+		var dep *Computed
+		for dep == nil && f.next < len(f.deps) && !node.HasFlag(FlagDirty) {
+			dep = f.deps[f.next].computed
+			f.next++
+		}
+
+		if dep != nil {
+			stack = append(stack, frame{node: dep})
+			continue
+		}
+
+		if node.HasFlag(FlagDirty) {
+			r.recompute(node)
+		} else {
+			node.RemoveFlag(FlagCheck)
+		}
+
+		stack = stack[:len(stack)-1]
+	}
+}
+
+// markSubs marks the signal's subscribers with the given flag, see mark.
+func (r *Runtime) markSubs(s *Signal, flag NodeFlags) {
+	for _, sub := range slices.Collect(s.Subs()) {
+		r.mark(sub, flag)
+	}
+}
+
+// mark flags the node as dirty or to be checked, and the nodes depending on it as to be checked.
+// Eager nodes are scheduled for an update, others are updated once read.
+func (r *Runtime) mark(node *Computed, flag NodeFlags) {
+	if node.HasFlag(FlagDirty) || node.HasFlag(flag) {
+		return
+	}
+
+	// the nodes depending on it have already been marked
+	marked := node.HasFlag(FlagCheck)
+	node.AddFlag(flag)
+
+	if node.eager && !node.isPaused() {
+		r.mu.Lock()
+		r.heap.Insert(node)
+		r.mu.Unlock()
+	}
+
+	if !marked {
+		r.markSubs(node.Signal, FlagCheck)
 	}
 }
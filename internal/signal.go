@@ -9,6 +9,12 @@ import (
 type Signal struct {
 	*ReactiveNode
 
+	// the runtime the signal was created in, its subscribers are updated within it
+	runtime *Runtime
+
+	// the computed holding the signal, nil for plain signals
+	computed *Computed
+
 	mu           sync.RWMutex
 	value        any
 	pendingValue *any // nil if no pending value
@@ -21,34 +27,85 @@ type Signal struct {
 func (r *Runtime) NewSignal(initial any) *Signal {
 	s := &Signal{
 		ReactiveNode: r.NewNode(),
+		runtime:      r,
 		value:        initial,
-		predicate:    defaultPredicate,
+		predicate:    DefaultPredicate,
 	}
 
 	return s
 }
 
 func (s *Signal) Read() any {
-	GetRuntime().tracker.Track(s)
+	tracker := GetRuntime().tracker
+	tracker.Track(s)
+
+	// interrupt the current scope, it will be run again once the node resolves
+	if s.HasFlag(FlagPending) && tracker.ShouldSuspend() {
+		panic(ErrPending)
+	}
 
 	return s.Value()
 }
 
 func (s *Signal) Write(v any) {
+	s.Update(func(any) any { return v })
+}
+
+// Update sets the signal's value to the result of fn called with the current one.
+// Fn is called with the signal locked, so that concurrent updates are never lost. It must not read the signal.
+func (s *Signal) Update(fn func(any) any) {
+	r := GetRuntime()
+	if r == s.runtime {
+		// already running within the runtime, e.g. from an effect
+		if r.exec.Held() {
+			s.update(fn)
+			return
+		}
+
+		s.runtime.Dispatch(func() { s.update(fn) })
+		return
+	}
+
+	// written from another runtime, hold the write back until the writer's batch completes
+	if r.batcher.IsBatching() {
+		r.deferWrite(s.runtime, func() { s.update(fn) })
+		return
+	}
+
+	s.runtime.Dispatch(func() { s.update(fn) })
+
+	// the writer's runtime settles as well
+	r.Dispatch(func() { r.Schedule(true) })
+}
+
+// update sets the signal's value, it must be run within the signal's runtime.
+func (s *Signal) update(fn func(any) any) {
+	if s.set(fn) {
+		s.notify()
+	}
+}
+
+// set sets the signal's value to the result of fn, and reports whether it changed.
+func (s *Signal) set(fn func(any) any) bool {
 	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := fn(s.valueUnsafe())
 	if s.equals(v) {
-		s.mu.Unlock()
-		return
+		return false
 	}
 
 	s.pendingValue = &v
-	s.mu.Unlock()
+	return true
+}
 
-	r := GetRuntime()
+// notify schedules the signal's subscribers for an update, it must be run within the signal's runtime.
+func (s *Signal) notify() {
+	r := s.runtime
 
 	r.mu.Lock()
 	s.SetVersion(r.scheduler.Time())
-	r.heap.InsertAll(s.Subs())
+	r.markSubs(s, FlagDirty)
 	r.mu.Unlock()
 
 	r.Schedule(true)
@@ -145,7 +202,8 @@ func (s *Signal) equals(value any) (result bool) {
 	return s.predicate(s.valueUnsafe(), value)
 }
 
-func defaultPredicate(a, b any) bool {
+// DefaultPredicate compares values with == if they are comparable, or reflect.DeepEqual otherwise.
+func DefaultPredicate(a, b any) bool {
 	if a == nil && b == nil {
 		return true
 	}
@@ -0,0 +1,93 @@
+package internal
+
+import "sync"
+
+// Suspense is an owner keeping track of the async nodes beneath it that have not resolved yet.
+// While any of them is pending, render effects beneath it are held back.
+type Suspense struct {
+	*Owner
+
+	mu sync.Mutex
+
+	// pending async nodes beneath the boundary
+	nodes map[*AsyncComputed]struct{}
+
+	// render effects waiting for all nodes to resolve
+	held []*Effect
+
+	// holds the number of pending nodes
+	count *Signal
+}
+
+func (r *Runtime) NewSuspense() *Suspense {
+	s := &Suspense{
+		Owner: r.NewOwner(),
+		nodes: make(map[*AsyncComputed]struct{}),
+		count: r.NewSignal(0),
+	}
+
+	s.Owner.suspense = s
+
+	return s
+}
+
+// Count returns the number of pending async nodes beneath the boundary.
+func (s *Suspense) Count() int {
+	return s.count.Read().(int)
+}
+
+// sync updates the boundary with the current pending state of the given node.
+func (s *Suspense) sync(node *AsyncComputed) {
+	s.mu.Lock()
+	pending := node.HasFlag(FlagPending)
+	if _, tracked := s.nodes[node]; pending == tracked {
+		s.mu.Unlock()
+		return
+	}
+
+	if pending {
+		s.nodes[node] = struct{}{}
+	} else {
+		delete(s.nodes, node)
+	}
+
+	var count any = len(s.nodes)
+	s.count.mu.Lock()
+	s.count.pendingValue = &count
+	s.count.mu.Unlock()
+
+	var held []*Effect
+	if len(s.nodes) == 0 {
+		held, s.held = s.held, nil
+	}
+	s.mu.Unlock()
+
+	r := s.count.runtime
+	r.Dispatch(func() {
+		s.count.notify()
+
+		if len(held) > 0 {
+			r.mu.Lock()
+			for _, effect := range held {
+				r.mark(effect.Computed, FlagDirty)
+			}
+			r.mu.Unlock()
+
+			r.Schedule(true)
+		}
+	})
+}
+
+// hold keeps the given effect from running if the boundary has pending nodes.
+// It reports whether the effect was held.
+func (s *Suspense) hold(effect *Effect) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.nodes) == 0 {
+		return false
+	}
+
+	s.held = append(s.held, effect)
+	return true
+}
@@ -8,6 +8,8 @@ type Tracker struct {
 	mu sync.RWMutex
 
 	tracking bool
+	detached bool // set once the tracked computation went stale
+	probing  int  // > 0 when checking for pending nodes with IsPending
 
 	executingGID       int64     // to prevent cross-goroutine tracking issues
 	currentOwner       *Owner    // for lifecycle/cleanup tracking
@@ -38,6 +40,17 @@ func (t *Tracker) CurrentComputation() *Computed {
 	return t.currentComputation
 }
 
+// TrackingComputation returns the computation reads are currently tracked by, nil if they are not tracked.
+func (t *Tracker) TrackingComputation() *Computed {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.shouldTrack(nil) {
+		return nil
+	}
+	return t.currentComputation
+}
+
 func (t *Tracker) RunWithOwner(owner *Owner, fn func()) {
 	defer owner.recover()
 
@@ -95,6 +108,35 @@ func (t *Tracker) RunUntracked(fn func()) {
 	fn()
 }
 
+// Detach stops any further tracking, used when the computation being tracked went stale.
+func (t *Tracker) Detach() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.detached = true
+}
+
+// RunProbing runs fn, making reads of pending nodes interrupt it even outside of a computation.
+func (t *Tracker) RunProbing(fn func()) {
+	t.mu.Lock()
+	t.probing++
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		t.probing--
+		t.mu.Unlock()
+	}()
+
+	fn()
+}
+
+// ShouldSuspend reports whether reading a pending node should interrupt the current scope.
+func (t *Tracker) ShouldSuspend() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.probing > 0 || t.shouldTrack(nil)
+}
+
 func (t *Tracker) Track(node *Signal) {
 	t.mu.RLock()
 	shouldTrack := t.shouldTrack(node)
@@ -115,5 +157,5 @@ func (t *Tracker) shouldTrack(node *Signal) bool {
 	// to avoid cross-goroutine tracking issues
 	isSameGID := callerGID == t.executingGID
 
-	return hasOwner && isTracking && isSameGID
+	return hasOwner && isTracking && isSameGID && !t.detached
 }
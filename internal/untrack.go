@@ -1,5 +1,5 @@
 package internal
 
 func (r *Runtime) Untrack(fn func()) {
-	r.tracker.RunUntracked(fn)
+	r.Run(func() { r.tracker.RunUntracked(fn) })
 }
@@ -0,0 +1,74 @@
+// Package op provides combinators deriving reactive values from other ones.
+//
+// Each combinator is a computed created with sig.NewComputed, it belongs to the current owner
+// and is disposed along with it.
+package op
+
+import "github.com/AnatoleLucet/sig"
+
+// Map derives a value from r with fn.
+func Map[T, U any](r sig.Readable[T], fn func(T) U) *sig.Computed[U] {
+	return sig.NewComputed(func() U {
+		return fn(r.Read())
+	})
+}
+
+// Combine2 derives a value from a and b with fn.
+func Combine2[A, B, R any](a sig.Readable[A], b sig.Readable[B], fn func(A, B) R) *sig.Computed[R] {
+	return sig.NewComputed(func() R {
+		return fn(a.Read(), b.Read())
+	})
+}
+
+// Combine3 derives a value from a, b and c with fn.
+func Combine3[A, B, C, R any](a sig.Readable[A], b sig.Readable[B], c sig.Readable[C], fn func(A, B, C) R) *sig.Computed[R] {
+	return sig.NewComputed(func() R {
+		return fn(a.Read(), b.Read(), c.Read())
+	})
+}
+
+// CombineN derives a value from the values of rs with fn, in the same order.
+func CombineN[T, R any](rs []sig.Readable[T], fn func([]T) R) *sig.Computed[R] {
+	return sig.NewComputed(func() R {
+		values := make([]T, len(rs))
+		for i, r := range rs {
+			values[i] = r.Read()
+		}
+
+		return fn(values)
+	})
+}
+
+// Filter holds the last value of r for which pred returns true, or fallback until there is one.
+func Filter[T any](r sig.Readable[T], pred func(T) bool, fallback T) *sig.Computed[T] {
+	last := fallback
+
+	return sig.NewComputed(func() T {
+		if v := r.Read(); pred(v) {
+			last = v
+		}
+
+		return last
+	})
+}
+
+// Scan accumulates the successive values of r with reducer, starting from seed.
+// Like any computed, it only sees the values r holds when it is recomputed:
+// several writes within a batch, or while it is not observed, are seen once.
+func Scan[T, A any](r sig.Readable[T], seed A, reducer func(acc A, v T) A) *sig.Computed[A] {
+	acc := seed
+
+	return sig.NewComputed(func() A {
+		acc = reducer(acc, r.Read())
+		return acc
+	})
+}
+
+// Distinct holds the value of r, only notifying its dependents when the key of the value changes.
+func Distinct[T any, K comparable](r sig.Readable[T], key func(T) K) *sig.Computed[T] {
+	return sig.NewComputed(func() T {
+		return r.Read()
+	}, sig.ComputedOptions[T]{
+		Equals: func(a, b T) bool { return key(a) == key(b) },
+	})
+}
@@ -0,0 +1,217 @@
+package op
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/AnatoleLucet/sig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("derives value", func(t *testing.T) {
+		count := sig.NewSignal(1)
+		label := Map(count, func(c int) string { return fmt.Sprintf("count %d", c) })
+
+		assert.Equal(t, "count 1", label.Read())
+
+		count.Write(2)
+		assert.Equal(t, "count 2", label.Read())
+	})
+
+	t.Run("chains", func(t *testing.T) {
+		count := sig.NewSignal(1)
+		double := Map(count, func(c int) int { return c * 2 })
+		label := Map(double, func(d int) string { return fmt.Sprint(d) })
+
+		count.Write(10)
+		assert.Equal(t, "20", label.Read())
+	})
+}
+
+func TestCombine(t *testing.T) {
+	t.Run("combine2", func(t *testing.T) {
+		first := sig.NewSignal("bob")
+		last := sig.NewSignal("smith")
+		full := Combine2(first, last, func(f, l string) string { return f + " " + l })
+
+		assert.Equal(t, "bob smith", full.Read())
+
+		last.Write("jones")
+		assert.Equal(t, "bob jones", full.Read())
+	})
+
+	t.Run("combine3", func(t *testing.T) {
+		a := sig.NewSignal(1)
+		b := sig.NewSignal("x")
+		c := sig.NewSignal(true)
+		joined := Combine3(a, b, c, func(a int, b string, c bool) string { return fmt.Sprintf("%d %s %t", a, b, c) })
+
+		assert.Equal(t, "1 x true", joined.Read())
+	})
+
+	t.Run("combineN", func(t *testing.T) {
+		a := sig.NewSignal("a")
+		b := sig.NewSignal("b")
+		c := sig.NewComputed(func() string { return strings.ToUpper(a.Read()) })
+		joined := CombineN([]sig.Readable[string]{a, b, c}, func(values []string) string {
+			return strings.Join(values, ",")
+		})
+
+		assert.Equal(t, "a,b,A", joined.Read())
+
+		a.Write("z")
+		assert.Equal(t, "z,b,Z", joined.Read())
+	})
+
+	t.Run("runs effects once per change", func(t *testing.T) {
+		log := []string{}
+
+		a := sig.NewSignal(1)
+		b := sig.NewSignal(2)
+		sum := Combine2(a, b, func(a, b int) int { return a + b })
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("sum %d", sum.Read()))
+		})
+
+		sig.NewBatch(func() {
+			a.Write(10)
+			b.Write(20)
+		})
+
+		assert.Equal(t, []string{
+			"sum 3",
+			"sum 30",
+		}, log)
+	})
+}
+
+func TestFilter(t *testing.T) {
+	t.Run("holds the last accepted value", func(t *testing.T) {
+		count := sig.NewSignal(1)
+		even := Filter(count, func(c int) bool { return c%2 == 0 }, -1)
+
+		assert.Equal(t, -1, even.Read())
+
+		count.Write(2)
+		assert.Equal(t, 2, even.Read())
+
+		count.Write(3)
+		assert.Equal(t, 2, even.Read())
+
+		count.Write(4)
+		assert.Equal(t, 4, even.Read())
+	})
+}
+
+func TestScan(t *testing.T) {
+	t.Run("accumulates values", func(t *testing.T) {
+		log := []string{}
+
+		count := sig.NewSignal(1)
+		total := Scan(count, 0, func(acc, c int) int { return acc + c })
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("total %d", total.Read()))
+		})
+
+		count.Write(2)
+		count.Write(3)
+
+		assert.Equal(t, []string{
+			"total 1",
+			"total 3",
+			"total 6",
+		}, log)
+	})
+
+	t.Run("collects history", func(t *testing.T) {
+		name := sig.NewSignal("a")
+		history := Scan(name, []string{}, func(acc []string, n string) []string { return append(acc, n) })
+
+		sig.NewEffect(func() { history.Read() })
+
+		name.Write("b")
+		name.Write("c")
+
+		assert.Equal(t, []string{"a", "b", "c"}, history.Read())
+	})
+}
+
+func TestDistinct(t *testing.T) {
+	type user struct {
+		id   int
+		name string
+	}
+
+	t.Run("notifies when the key changes", func(t *testing.T) {
+		log := []string{}
+
+		current := sig.NewSignal(&user{1, "bob"})
+		distinct := Distinct(current, func(u *user) int { return u.id })
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("user %s", distinct.Read().name))
+		})
+
+		current.Write(&user{1, "bobby"})
+		current.Write(&user{2, "alice"})
+
+		assert.Equal(t, []string{
+			"user bob",
+			"user alice",
+		}, log)
+	})
+
+	t.Run("waits for pending values", func(t *testing.T) {
+		gate := make(chan struct{})
+		done := make(chan string)
+
+		async := sig.NewAsyncComputed(func() (*user, error) {
+			<-gate
+			return &user{1, "bob"}, nil
+		})
+		loaded := sig.NewComputed(func() *user {
+			u, _ := async.Read()
+			return u
+		})
+		distinct := Distinct(loaded, func(u *user) int { return u.id })
+
+		sig.NewEffect(func() {
+			if u := distinct.Read(); u != nil {
+				done <- u.name
+			}
+		})
+
+		close(gate)
+		assert.Equal(t, "bob", <-done)
+	})
+}
+
+func TestOwnership(t *testing.T) {
+	t.Run("disposed with the owner", func(t *testing.T) {
+		log := []string{}
+
+		count := sig.NewSignal(1)
+
+		o := sig.NewOwner()
+		o.Run(func() error {
+			double := Map(count, func(c int) int {
+				log = append(log, fmt.Sprintf("mapping %d", c))
+				return c * 2
+			})
+
+			sig.NewEffect(func() { double.Read() })
+			return nil
+		})
+
+		o.Dispose()
+		count.Write(2)
+
+		assert.Equal(t, []string{
+			"mapping 1",
+		}, log)
+	})
+}
@@ -1,6 +1,15 @@
 package sig
 
-import "github.com/AnatoleLucet/sig/internal"
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/AnatoleLucet/sig/internal"
+)
+
+// ErrPending is returned when reading an async value that has not resolved yet outside of a reactive context.
+var ErrPending = internal.ErrPending
 
 func as[T any](v any) T {
 	if v == nil {
@@ -11,6 +20,45 @@ func as[T any](v any) T {
 	return v.(T)
 }
 
+// Readable is implemented by every reactive value that can be read: signals, computeds, contexts, and async computeds through AsyncComputed.Readable.
+type Readable[T any] interface {
+	// Read the current value, tracking the dependency if within a reactive context.
+	Read() T
+
+	// Peek the current value without tracking the dependency.
+	Peek() T
+}
+
+// Dependency is implemented by the reactive values an effect created with On can depend on, whatever the type of their value:
+// signals, computeds, derived signals, contexts, and async computeds through AsyncComputed.Readable.
+// Other readable values, e.g. store fields, can be turned into one with AsDependency.
+type Dependency interface {
+	// track reads the current value, tracking the dependency if within a reactive context.
+	track() any
+}
+
+// AsDependency turns any readable value into a Dependency.
+func AsDependency[T any](r Readable[T]) Dependency {
+	return readableDependency[T]{r}
+}
+
+type readableDependency[T any] struct {
+	readable Readable[T]
+}
+
+func (d readableDependency[T]) track() any { return d.readable.Read() }
+
+// Writable is implemented by reactive values that can also be written: signals and derived signals.
+type Writable[T any] interface {
+	Readable[T]
+
+	// Write a new value, triggering updates to any dependents.
+	Write(v T)
+
+	// Update the value with a function of the current one.
+	Update(fn func(T) T)
+}
+
 type SignalOptions[T any] struct {
 	Predicate func(a, b T) bool
 }
@@ -42,59 +90,571 @@ func (s *Signal[T]) Read() T {
 	return as[T](s.signal.Read())
 }
 
+// Peek the current value of the signal without tracking the dependency.
+// Unlike Read, it can be called from any goroutine without going through a runtime.
+func (s *Signal[T]) Peek() T {
+	return as[T](s.signal.Value())
+}
+
+func (s *Signal[T]) track() any { return s.Read() }
+
 // Write a new value to the signal, triggering updates to any dependents.
 func (s *Signal[T]) Write(v T) {
 	s.signal.Write(v)
 }
 
+// Update the signal with a function of its current value, without tracking the read.
+// The read and the write are atomic, so that concurrent updates from different goroutines are never lost.
+// The function must not read the signal itself.
+func (s *Signal[T]) Update(fn func(T) T) {
+	s.signal.Update(func(v any) any { return fn(as[T](v)) })
+}
+
+// NeverEqual is a predicate considering any two values different,
+// for signals and computeds that should always notify their dependents, e.g. when holding fresh pointers each time.
+func NeverEqual[T any](a, b T) bool {
+	return false
+}
+
+type ComputedOptions[T any] struct {
+	// Equals reports whether two values are the same, dependents are only updated when the value changes.
+	// Defaults to == for comparable types, and reflect.DeepEqual otherwise.
+	Equals func(a, b T) bool
+
+	// Initial value of the computed, held until its first computation resolves (e.g. while it reads a pending async value).
+	Initial T
+
+	// Name of the computed, for debugging purposes.
+	Name string
+}
+
 type Computed[T any] struct {
 	computed *internal.Computed
 }
 
 // NewComputed creates a computed signal that derives its value from other signals (its a memo).
-func NewComputed[T any](compute func() T) *Computed[T] {
+func NewComputed[T any](compute func() T, options ...ComputedOptions[T]) *Computed[T] {
 	return &Computed[T]{
 		internal.GetRuntime().NewComputed(func(c *internal.Computed) any {
 			return compute()
-		}),
+		}, computedOptions(options)),
+	}
+}
+
+func computedOptions[T any](options []ComputedOptions[T]) internal.ComputedOptions {
+	var opts ComputedOptions[T]
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	internalOpts := internal.ComputedOptions{
+		Initial: opts.Initial,
+		Name:    opts.Name,
+	}
+
+	if opts.Equals != nil {
+		internalOpts.Predicate = func(a, b any) bool {
+			return opts.Equals(as[T](a), as[T](b))
+		}
 	}
+
+	return internalOpts
 }
 
 // Read the current value of the computed signal, tracking the dependency if within a reactive context.
 func (c *Computed[T]) Read() T {
-	return as[T](c.computed.Signal.Read())
+	return as[T](c.computed.Read())
+}
+
+// Peek the current value of the computed signal without tracking the dependency.
+// Like Read, the value is recomputed first if one of its dependencies changed and it was not observed by any effect.
+func (c *Computed[T]) Peek() T {
+	return as[T](c.computed.Peek())
+}
+
+func (c *Computed[T]) track() any { return c.Read() }
+
+// Name returns the name given to the computed with ComputedOptions.Name, if any.
+func (c *Computed[T]) Name() string {
+	return c.computed.Name()
+}
+
+// Snapshot returns the last value computed, without recomputing it or going through its runtime.
+// Unlike Peek, it never waits for another goroutine running within the runtime, so it can be called
+// from anywhere, e.g. to scrape metrics. The value is stale if the computed is not observed and one of its dependencies changed.
+func (c *Computed[T]) Snapshot() T {
+	return as[T](c.computed.Value())
+}
+
+type DerivedSignal[T any] struct {
+	computed *internal.Computed
+}
+
+// NewDerivedSignal creates a writable signal deriving its value from other signals, like a computed.
+// The derive function is called with the previous value whenever the signals it reads change.
+// Writing to the signal overrides the derived value, until one of those signals changes and the value is derived again.
+func NewDerivedSignal[T any](derive func(prev T) T, options ...ComputedOptions[T]) *DerivedSignal[T] {
+	return &DerivedSignal[T]{
+		internal.GetRuntime().NewComputed(func(c *internal.Computed) any {
+			return derive(as[T](c.Value()))
+		}, computedOptions(options)),
+	}
+}
+
+// Read the current value of the signal, tracking the dependency if within a reactive context.
+func (s *DerivedSignal[T]) Read() T {
+	return as[T](s.computed.Read())
+}
+
+// Peek the current value of the signal without tracking the dependency.
+func (s *DerivedSignal[T]) Peek() T {
+	return as[T](s.computed.Peek())
+}
+
+func (s *DerivedSignal[T]) track() any { return s.Read() }
+
+// Write a new value to the signal, overriding the derived one until it is derived again.
+func (s *DerivedSignal[T]) Write(v T) {
+	s.computed.Write(v)
+}
+
+// Update the signal with a function of its current value, like Signal.Update.
+func (s *DerivedSignal[T]) Update(fn func(T) T) {
+	s.computed.Update(func(v any) any { return fn(as[T](v)) })
+}
+
+type Map[K comparable, V any] struct {
+	collection *internal.Collection
+
+	mu      sync.RWMutex
+	entries map[K]V
+	keys    order[K]
+}
+
+// NewMap creates a reactive map. Unlike a signal holding a map, reading an entry only tracks that entry:
+// Get and Has only rerun when the entry of their key changes, and Len and Keys only when keys are added or removed.
+func NewMap[K comparable, V any]() *Map[K, V] {
+	return &Map[K, V]{
+		collection: internal.GetRuntime().NewCollection(),
+		entries:    make(map[K]V),
+	}
+}
+
+// Get the value of the entry, or the zero value if there is none, tracking the entry if within a reactive context.
+func (m *Map[K, V]) Get(key K) V {
+	m.collection.TrackKey(key)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.entries[key]
+}
+
+// Has reports whether the map has an entry for the key, tracking the entry if within a reactive context.
+func (m *Map[K, V]) Has(key K) bool {
+	m.collection.TrackKey(key)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Set the value of the entry, adding it if needed. Nothing is updated if the entry already holds an equal value.
+func (m *Map[K, V]) Set(key K, v V) {
+	if changed, added := m.set(key, v); changed {
+		m.collection.Notify([]any{key}, added)
+	}
+}
+
+func (m *Map[K, V]) set(key K, v V) (changed, added bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.entries[key]
+	if ok && internal.DefaultPredicate(old, v) {
+		return false, false
+	}
+
+	if !ok {
+		m.keys.add(key)
+	}
+
+	m.entries[key] = v
+	return true, !ok
+}
+
+// Delete the entry of the key, if any.
+func (m *Map[K, V]) Delete(key K) {
+	if m.delete(key) {
+		m.collection.Notify([]any{key}, true)
+	}
+}
+
+func (m *Map[K, V]) delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.entries[key]; !ok {
+		return false
+	}
+
+	delete(m.entries, key)
+	m.keys.remove(key)
+	return true
+}
+
+// Len returns the number of entries, tracking the keys of the map if within a reactive context.
+func (m *Map[K, V]) Len() int {
+	m.collection.TrackStructure()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.entries)
+}
+
+// Keys returns the keys of the map in insertion order, tracking them if within a reactive context.
+// Changes to the values of the entries are not tracked.
+func (m *Map[K, V]) Keys() []K {
+	m.collection.TrackStructure()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.keys.all()
+}
+
+// All returns an iterator over the entries of the map in insertion order,
+// tracking the keys of the map and the entries iterated over if within a reactive context.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, key := range m.Keys() {
+			m.collection.TrackKey(key)
+
+			m.mu.RLock()
+			v, ok := m.entries[key]
+			m.mu.RUnlock()
+
+			// deleted while iterating
+			if !ok {
+				continue
+			}
+
+			if !yield(key, v) {
+				return
+			}
+		}
+	}
+}
+
+// order keeps keys in insertion order, adding and removing them in constant time.
+type order[K comparable] struct {
+	keys  []K       // in insertion order, along with the stale slots of removed keys
+	index map[K]int // slot of each key in keys
+}
+
+func (o *order[K]) add(key K) {
+	if o.index == nil {
+		o.index = make(map[K]int)
+	}
+
+	o.index[key] = len(o.keys)
+	o.keys = append(o.keys, key)
+}
+
+func (o *order[K]) remove(key K) {
+	delete(o.index, key)
+
+	// compact once most slots are stale
+	if len(o.keys) > 2*len(o.index) {
+		o.keys = o.all()
+		for i, key := range o.keys {
+			o.index[key] = i
+		}
+	}
+}
+
+// all returns the keys in insertion order.
+func (o *order[K]) all() []K {
+	keys := make([]K, 0, len(o.index))
+	for i, key := range o.keys {
+		if j, ok := o.index[key]; ok && i == j {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+type Set[T comparable] struct {
+	collection *internal.Collection
+
+	mu      sync.RWMutex
+	members map[T]struct{}
+	order   order[T]
+}
+
+// NewSet creates a reactive set. Has only reruns when the membership of its value changes,
+// and Len and All only when members are added or removed.
+func NewSet[T comparable](members ...T) *Set[T] {
+	s := &Set[T]{
+		collection: internal.GetRuntime().NewCollection(),
+		members:    make(map[T]struct{}),
+	}
+
+	for _, v := range members {
+		s.set(v, true)
+	}
+
+	return s
+}
+
+// Has reports whether v is a member of the set, tracking its membership if within a reactive context.
+func (s *Set[T]) Has(v T) bool {
+	s.collection.TrackKey(v)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.members[v]
+	return ok
+}
+
+// Add v to the set, if it is not a member already.
+func (s *Set[T]) Add(v T) {
+	if s.set(v, true) {
+		s.collection.Notify([]any{v}, true)
+	}
+}
+
+// Remove v from the set, if it is a member.
+func (s *Set[T]) Remove(v T) {
+	if s.set(v, false) {
+		s.collection.Notify([]any{v}, true)
+	}
+}
+
+// Toggle the membership of v, and report whether it is now a member.
+func (s *Set[T]) Toggle(v T) bool {
+	member := s.toggle(v)
+	s.collection.Notify([]any{v}, true)
+	return member
+}
+
+func (s *Set[T]) toggle(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, member := s.members[v]
+	s.setUnsafe(v, !member)
+	return !member
+}
+
+// set adds or removes v, and reports whether its membership changed.
+func (s *Set[T]) set(v T, member bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setUnsafe(v, member)
+}
+
+func (s *Set[T]) setUnsafe(v T, member bool) bool {
+	if _, ok := s.members[v]; ok == member {
+		return false
+	}
+
+	if member {
+		s.members[v] = struct{}{}
+		s.order.add(v)
+	} else {
+		delete(s.members, v)
+		s.order.remove(v)
+	}
+
+	return true
+}
+
+// Len returns the number of members, tracking the members of the set if within a reactive context.
+func (s *Set[T]) Len() int {
+	s.collection.TrackStructure()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.members)
 }
 
-type AsyncComputed[T any] struct{}
+// All returns an iterator over the members of the set in insertion order,
+// tracking the members of the set if within a reactive context.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.collection.TrackStructure()
+
+		s.mu.RLock()
+		members := s.order.all()
+		s.mu.RUnlock()
+
+		for _, v := range members {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+type AsyncComputed[T any] struct {
+	computed *internal.AsyncComputed
+}
 
-// NewAsyncComputed not implemented yet.
+// NewAsyncComputed creates a computed signal whose value is resolved in a goroutine.
+// The function is called again each time one of the signals it reads changes,
+// and its result is committed back to the runtime the computed was created in.
+// The cleanups registered and the nodes created by the function belong to its call,
+// they are disposed once the call has returned and a newer one started, or the computed is disposed.
 func NewAsyncComputed[T any](fn func() (T, error)) *AsyncComputed[T] {
-	return &AsyncComputed[T]{}
+	return NewAsyncComputedContext(func(context.Context) (T, error) {
+		return fn()
+	})
+}
+
+// NewAsyncComputedContext is like NewAsyncComputed, but the function receives a context
+// that is cancelled as soon as its result becomes stale: when one of its dependencies changes, or when its owner is disposed.
+func NewAsyncComputedContext[T any](fn func(ctx context.Context) (T, error)) *AsyncComputed[T] {
+	return &AsyncComputed[T]{
+		internal.GetRuntime().NewAsyncComputed(func(ctx context.Context) (any, error) {
+			return fn(ctx)
+		}),
+	}
 }
 
-// Read the current value of the async computed signal, tracking the dependency if within a reactive context.
+// Read the last resolved value of the async computed signal, tracking the dependency if within a reactive context.
+// While the computed is pending, reading it from a reactive context interrupts the context until the computed resolves.
+// Elsewhere, it returns the last resolved value along with ErrPending.
 func (c *AsyncComputed[T]) Read() (T, error) {
-	return *new(T), nil
+	result := as[internal.AsyncResult](c.computed.Read())
+	if c.computed.HasFlag(internal.FlagPending) {
+		return as[T](result.Value), ErrPending
+	}
+
+	return as[T](result.Value), result.Err
+}
+
+// Peek is like Read, without tracking the dependency.
+func (c *AsyncComputed[T]) Peek() (T, error) {
+	result := as[internal.AsyncResult](c.computed.Peek())
+	if c.computed.HasFlag(internal.FlagPending) {
+		return as[T](result.Value), ErrPending
+	}
+
+	return as[T](result.Value), result.Err
+}
+
+// Readable returns a view of the async computed's value, without its error, implementing Readable.
+// While the computed is pending, reading the view from a reactive context interrupts the context like Read does.
+func (c *AsyncComputed[T]) Readable() Readable[T] {
+	return asyncReadable[T]{c}
+}
+
+type asyncReadable[T any] struct {
+	computed *AsyncComputed[T]
+}
+
+func (r asyncReadable[T]) Read() T {
+	v, _ := r.computed.Read()
+	return v
+}
+
+func (r asyncReadable[T]) Peek() T {
+	v, _ := r.computed.Peek()
+	return v
 }
 
+func (r asyncReadable[T]) track() any { return r.Read() }
+
 // NewBatch batches multiple signal writes into a single update cycle,
 // instead of triggering updates after each write.
 func NewBatch(fn func()) {
-	internal.GetRuntime().NewBatch(fn)
+	CurrentRuntime().NewBatch(fn)
+}
+
+type Effect struct {
+	effect *internal.Effect
 }
 
 // NewEffect creates a reactive effect that runs the given function
 // whenever its dependencies change.
-func NewEffect(fn func()) {
-	internal.GetRuntime().NewEffect(internal.EffectUser, fn)
+func NewEffect(fn func()) *Effect {
+	return CurrentRuntime().NewEffect(fn)
 }
 
 // NewRenderEffect creates a reactive effect specifically for rendering purposes.
 // Render effects runs before regular effects to ensure the UI is updated promptly.
-func NewRenderEffect(fn func()) {
-	internal.GetRuntime().NewEffect(internal.EffectRender, fn)
+func NewRenderEffect(fn func()) *Effect {
+	return CurrentRuntime().NewRenderEffect(fn)
+}
+
+// NewEffectWith creates an effect split in two phases.
+// Only compute tracks dependencies, it is called with its previous result whenever they change.
+// Apply is then called untracked along with the other effects, with the computed value and the previous one,
+// and only if the value changed. The function it returns, if any, is called before the next apply and on disposal.
+func NewEffectWith[T any](compute func(prev T) T, apply func(next, prev T) func()) *Effect {
+	return newEffectWith(internal.EffectUser, compute, apply)
+}
+
+// NewRenderEffectWith is like NewEffectWith, but apply is called along with render effects.
+func NewRenderEffectWith[T any](compute func(prev T) T, apply func(next, prev T) func()) *Effect {
+	return newEffectWith(internal.EffectRender, compute, apply)
+}
+
+func newEffectWith[T any](typ internal.EffectType, compute func(prev T) T, apply func(next, prev T) func()) *Effect {
+	return &Effect{
+		internal.GetRuntime().NewEffectWith(typ,
+			func(prev any) any { return compute(as[T](prev)) },
+			func(next, prev any) func() { return apply(as[T](next), as[T](prev)) },
+		),
+	}
+}
+
+type OnOptions struct {
+	// Defer skips the first run, the function is only called once one of the dependencies changes.
+	Defer bool
 }
 
+// On creates an effect depending on deps only, which can hold values of different types. The function is called untracked
+// with the current values of deps, in the same order, and the values they had on the previous run (nil on the first one).
+// Reactive values read within the function do not make the effect run again.
+func On(fn func(values, prev []any), deps ...Dependency) *Effect {
+	return OnWithOptions(OnOptions{}, fn, deps...)
+}
+
+// OnWithOptions is like On, with the given options.
+func OnWithOptions(options OnOptions, fn func(values, prev []any), deps ...Dependency) *Effect {
+	return &Effect{
+		internal.GetRuntime().NewEffectOn(internal.EffectUser,
+			func() any {
+				values := make([]any, len(deps))
+				for i, dep := range deps {
+					values[i] = dep.track()
+				}
+
+				return values
+			},
+			func(next, prev any) { fn(as[[]any](next), as[[]any](prev)) },
+			options.Defer,
+		),
+	}
+}
+
+// Dispose the effect, running its cleanups and stopping it from running again.
+func (e *Effect) Dispose() { e.effect.Dispose() }
+
+// IsDisposed reports whether the effect has been disposed, either directly or along with its owner.
+func (e *Effect) IsDisposed() bool { return e.effect.HasFlag(internal.FlagDisposed) }
+
+// Pause the effect: it does not run while paused, but keeps track of its dependencies.
+func (e *Effect) Pause() { e.effect.Pause() }
+
+// Resume a paused effect. It runs once if any of its dependencies changed while it was paused.
+func (e *Effect) Resume() { e.effect.Resume() }
+
+// Owner returns the owner of the effect, which owns the reactive nodes created within the effect.
+func (e *Effect) Owner() *Owner { return &Owner{e.effect.Owner} }
+
 // Untrack runs the given function without tracking any reactive dependencies.
 func Untrack[T any](fn func() T) T {
 	var result T
@@ -102,9 +662,10 @@ func Untrack[T any](fn func() T) T {
 	return result
 }
 
-// IsPending not implemented yet.
+// IsPending runs the given function and reports whether it read an async value that has not resolved yet,
+// either directly or through a computed depending on it.
 func IsPending(fn func()) bool {
-	return false
+	return internal.GetRuntime().IsPending(fn)
 }
 
 // OnCleanup registers a function to be called when the current owner is disposed.
@@ -115,19 +676,19 @@ func OnCleanup(fn func()) {
 // OnSettled registers a function to be called once, after the current runtime
 // has finished processing all pending updates and effects.
 func OnSettled(fn func()) {
-	internal.GetRuntime().OnSettled(fn)
+	CurrentRuntime().OnSettled(fn)
 }
 
 // OnUserSettled registers a function to be called once, after all user effects have been processed.
 // Note that user effects scheduled during the execution of this function will not trigger another call.
 func OnUserSettled(fn func()) {
-	internal.GetRuntime().OnUserSettled(fn)
+	CurrentRuntime().OnUserSettled(fn)
 }
 
 // OnRenderSettled registers a function to be called once, after all render effects have been processed.
 // Note that render effects scheduled during the execution of this function will not trigger another call.
 func OnRenderSettled(fn func()) {
-	internal.GetRuntime().OnRenderSettled(fn)
+	CurrentRuntime().OnRenderSettled(fn)
 }
 
 type Context[T any] struct {
@@ -147,6 +708,18 @@ func (c *Context[T]) Value() T {
 	return as[T](c.ctx.Value())
 }
 
+// Read is the same as Value, contexts are not tracked. It implements Readable.
+func (c *Context[T]) Read() T {
+	return c.Value()
+}
+
+// Peek is the same as Value, contexts are not tracked. It implements Readable.
+func (c *Context[T]) Peek() T {
+	return c.Value()
+}
+
+func (c *Context[T]) track() any { return c.Read() }
+
 // Set a new value for the context in the current owner.
 func (c *Context[T]) Set(value T) {
 	c.ctx.Set(value)
@@ -159,9 +732,7 @@ type Owner struct {
 // NewOwner creates a new reactive owner.
 // An owner manages the lifecycle of reactive nodes created within its context.
 func NewOwner() *Owner {
-	return &Owner{
-		internal.GetRuntime().NewOwner(),
-	}
+	return CurrentRuntime().NewOwner()
 }
 
 // Run a function within the context of this owner.
@@ -178,3 +749,127 @@ func (o *Owner) OnCleanup(fn func()) { o.owner.OnCleanup(fn) }
 // Add a function to be called when a panic occurs within this owner.
 // If no error listener is registered, the panic will propagate as usual.
 func (o *Owner) OnError(fn func(any)) { o.owner.OnError(fn) }
+
+type Suspense struct {
+	*Owner
+	suspense *internal.Suspense
+}
+
+// NewSuspense creates an owner acting as a suspense boundary.
+// It keeps track of the async computeds created beneath it that have not resolved yet,
+// and holds back the render effects beneath it until all of them are resolved.
+func NewSuspense() *Suspense {
+	s := internal.GetRuntime().NewSuspense()
+	return &Suspense{&Owner{s.Owner}, s}
+}
+
+// Pending returns the number of async computeds beneath the boundary that have not resolved yet,
+// tracking the dependency if within a reactive context.
+func (s *Suspense) Pending() int { return s.suspense.Count() }
+
+type Runtime struct {
+	runtime *internal.Runtime
+}
+
+// NewRuntime creates a runtime that is not tied to any goroutine.
+// Reactive nodes are created, read and written within it using Runtime.Run, from any goroutine.
+func NewRuntime() *Runtime {
+	return &Runtime{internal.NewRuntime()}
+}
+
+// CurrentRuntime returns the runtime reactive nodes are currently handled by:
+// the one entered with Runtime.Run if any, or else the current goroutine's own runtime.
+func CurrentRuntime() *Runtime {
+	return &Runtime{internal.GetRuntime()}
+}
+
+// DisposeRuntime disposes the current goroutine's own runtime: every owner created
+// without a parent in it is disposed, and any pending update or callback is dropped.
+// A fresh runtime is created the next time the goroutine uses one.
+//
+// Runtimes of goroutines that have exited are also reclaimed automatically,
+// DisposeRuntime is only needed to tear down the reactive nodes a goroutine created.
+func DisposeRuntime() {
+	internal.DisposeRuntime()
+}
+
+// Dispose every owner created without a parent in this runtime, and drop any pending update or callback.
+func (rt *Runtime) Dispose() { rt.runtime.Dispose() }
+
+// Run a function within this runtime, no matter which goroutine it is called from.
+// Runs are serialized: while a goroutine runs within the runtime, other goroutines calling Run wait for it to return.
+func (rt *Runtime) Run(fn func()) { rt.runtime.Run(fn) }
+
+// Flush processes pending updates and effects right away, even within a batch.
+func (rt *Runtime) Flush() { rt.runtime.Run(rt.runtime.Flush) }
+
+// NewBatch is like the package level NewBatch, but within this runtime.
+func (rt *Runtime) NewBatch(fn func()) {
+	rt.runtime.Run(func() { rt.runtime.NewBatch(fn) })
+}
+
+// NewEffect is like the package level NewEffect, but within this runtime.
+func (rt *Runtime) NewEffect(fn func()) *Effect {
+	var effect *internal.Effect
+	rt.runtime.Run(func() { effect = rt.runtime.NewEffect(internal.EffectUser, fn) })
+	return &Effect{effect}
+}
+
+// NewRenderEffect is like the package level NewRenderEffect, but within this runtime.
+func (rt *Runtime) NewRenderEffect(fn func()) *Effect {
+	var effect *internal.Effect
+	rt.runtime.Run(func() { effect = rt.runtime.NewEffect(internal.EffectRender, fn) })
+	return &Effect{effect}
+}
+
+// NewOwner is like the package level NewOwner, but within this runtime.
+func (rt *Runtime) NewOwner() *Owner {
+	var owner *internal.Owner
+	rt.runtime.Run(func() { owner = rt.runtime.NewOwner() })
+	return &Owner{owner}
+}
+
+// OnSettled is like the package level OnSettled, but within this runtime.
+func (rt *Runtime) OnSettled(fn func()) {
+	rt.runtime.Run(func() { rt.runtime.OnSettled(fn) })
+}
+
+// OnUserSettled is like the package level OnUserSettled, but within this runtime.
+func (rt *Runtime) OnUserSettled(fn func()) {
+	rt.runtime.Run(func() { rt.runtime.OnUserSettled(fn) })
+}
+
+// OnRenderSettled is like the package level OnRenderSettled, but within this runtime.
+func (rt *Runtime) OnRenderSettled(fn func()) {
+	rt.runtime.Run(func() { rt.runtime.OnRenderSettled(fn) })
+}
+
+// ErrLoopStopped is returned when dispatching to a loop that has been stopped.
+var ErrLoopStopped = internal.ErrLoopStopped
+
+type Loop struct {
+	loop *internal.Loop
+}
+
+// NewLoop starts a goroutine owning a runtime, which runs the functions dispatched to it one after the other.
+// Each dispatched function is run in its own batch, so that reactive state living on the loop
+// can be updated from any goroutine without races.
+// Writes to the loop's signals from other goroutines are dispatched to it as well, effects only ever run on the loop.
+func NewLoop() *Loop {
+	return &Loop{internal.NewLoop()}
+}
+
+// Runtime returns the runtime owned by the loop.
+func (l *Loop) Runtime() *Runtime { return &Runtime{l.loop.Runtime()} }
+
+// Dispatch queues a function to be run on the loop without waiting for it.
+// Functions dispatched after the loop is stopped are ignored.
+func (l *Loop) Dispatch(fn func()) { l.loop.Dispatch(fn) }
+
+// DispatchSync runs a function on the loop and waits for it, and for the updates it triggered, to complete.
+// It returns ErrLoopStopped if the loop is stopped, or an error if the function panicked.
+func (l *Loop) DispatchSync(fn func()) error { return l.loop.DispatchSync(fn) }
+
+// Stop the loop once the functions already dispatched to it have run,
+// then dispose every owner created without a parent on the loop.
+func (l *Loop) Stop() { l.loop.Stop() }
@@ -0,0 +1,364 @@
+package sig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncComputed(t *testing.T) {
+	t.Run("resolves in a goroutine", func(t *testing.T) {
+		var mu sync.Mutex
+		log := []string{}
+		done := make(chan struct{})
+
+		user := NewAsyncComputed(func() (string, error) {
+			return "bob", nil
+		})
+
+		NewEffect(func() {
+			u, err := user.Read()
+
+			mu.Lock()
+			log = append(log, fmt.Sprintf("user %q %v", u, err))
+			mu.Unlock()
+
+			if u != "" {
+				close(done)
+			}
+		})
+
+		<-done
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{
+			`user "bob" <nil>`,
+		}, log)
+	})
+
+	t.Run("resolves errors", func(t *testing.T) {
+		done := make(chan error, 1)
+
+		user := NewAsyncComputed(func() (string, error) {
+			return "", errors.New("not found")
+		})
+
+		NewEffect(func() {
+			if _, err := user.Read(); err != nil {
+				done <- err
+			}
+		})
+
+		assert.EqualError(t, <-done, "not found")
+	})
+
+	t.Run("reruns when dependencies change", func(t *testing.T) {
+		results := make(chan string, 10)
+
+		userID := NewSignal(1)
+		user := NewAsyncComputed(func() (string, error) {
+			return fmt.Sprintf("user %d", userID.Read()), nil
+		})
+
+		NewEffect(func() {
+			if u, _ := user.Read(); u != "" {
+				results <- u
+			}
+		})
+
+		assert.Equal(t, "user 1", <-results)
+
+		userID.Write(2)
+		assert.Equal(t, "user 2", <-results)
+	})
+
+	t.Run("discards stale results", func(t *testing.T) {
+		results := make(chan string, 10)
+		gates := map[int]chan struct{}{
+			1: make(chan struct{}),
+			2: make(chan struct{}),
+		}
+
+		started := make(chan struct{})
+
+		userID := NewSignal(1)
+		user := NewAsyncComputed(func() (string, error) {
+			id := userID.Read()
+			if id == 1 {
+				close(started)
+			}
+			<-gates[id]
+			return fmt.Sprintf("user %d", id), nil
+		})
+
+		NewEffect(func() {
+			if u, _ := user.Read(); u != "" {
+				results <- u
+			}
+		})
+
+		<-started
+		userID.Write(2)
+		close(gates[2])
+		assert.Equal(t, "user 2", <-results)
+
+		// first run resolves late, it should not override the latest value
+		close(gates[1])
+		assert.Never(t, func() bool { return len(results) > 0 }, 50*time.Millisecond, 5*time.Millisecond)
+
+		u, err := user.Read()
+		assert.Equal(t, "user 2", u)
+		assert.NoError(t, err)
+	})
+
+	t.Run("commits to the creating runtime", func(t *testing.T) {
+		settled := make(chan struct{})
+
+		// only runs once the runtime this test is running in is flushed
+		OnSettled(func() { close(settled) })
+
+		user := NewAsyncComputed(func() (string, error) {
+			return "bob", nil
+		})
+
+		NewEffect(func() { user.Read() })
+
+		<-settled
+
+		u, _ := user.Read()
+		assert.Equal(t, "bob", u)
+	})
+
+	t.Run("recomputes downstream nodes once", func(t *testing.T) {
+		var mu sync.Mutex
+		log := []string{}
+		done := make(chan struct{})
+
+		user := NewAsyncComputed(func() (string, error) {
+			return "bob", nil
+		})
+		upper := NewComputed(func() string {
+			u, _ := user.Read()
+			return fmt.Sprintf("<%s>", u)
+		})
+
+		NewEffect(func() {
+			u, _ := user.Read()
+
+			mu.Lock()
+			log = append(log, fmt.Sprintf("%s %s", u, upper.Read()))
+			mu.Unlock()
+
+			if u != "" {
+				close(done)
+			}
+		})
+
+		<-done
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{
+			"bob <bob>",
+		}, log)
+	})
+
+	t.Run("disposal discards in-flight results", func(t *testing.T) {
+		gate := make(chan struct{})
+		returned := make(chan struct{})
+		runs := 0
+
+		o := NewOwner()
+
+		var user *AsyncComputed[string]
+		o.Run(func() error {
+			user = NewAsyncComputed(func() (string, error) {
+				defer close(returned)
+				<-gate
+				return "bob", nil
+			})
+
+			NewEffect(func() {
+				user.Read()
+				runs++
+			})
+
+			return nil
+		})
+
+		o.Dispose()
+		close(gate)
+		<-returned
+
+		assert.Never(t, func() bool {
+			u, _ := user.Read()
+			return u != ""
+		}, 50*time.Millisecond, 5*time.Millisecond)
+		assert.Equal(t, 0, runs)
+	})
+
+	t.Run("owns the cleanups and nodes created by each run", func(t *testing.T) {
+		var runs, cleaned, early atomic.Int64
+		results := make(chan int, 1)
+
+		id := NewSignal(0)
+		user := NewAsyncComputedContext(func(ctx context.Context) (int, error) {
+			runs.Add(1)
+			v := id.Read()
+
+			var returned atomic.Bool
+			defer returned.Store(true)
+
+			OnCleanup(func() {
+				if !returned.Load() {
+					early.Add(1)
+				}
+				cleaned.Add(1)
+			})
+			double := NewComputed(func() int { return v * 2 })
+
+			// stale runs are still in flight while the node recomputes
+			if v < 10 {
+				<-ctx.Done()
+			}
+			return double.Read() / 2, nil
+		})
+
+		NewEffect(func() {
+			if u, _ := user.Read(); u == 10 {
+				results <- u
+			}
+		})
+
+		for i := range 10 {
+			time.Sleep(time.Millisecond)
+			id.Write(i + 1)
+		}
+
+		assert.Equal(t, 10, <-results)
+
+		// every run but the last one is cleaned up once it is both stale and finished
+		assert.Eventually(t, func() bool { return cleaned.Load() == runs.Load()-1 }, time.Second, time.Millisecond)
+		assert.Zero(t, early.Load(), "runs cleaned up while still running")
+	})
+}
+
+func TestAsyncComputedContext(t *testing.T) {
+	t.Run("cancels when dependencies change", func(t *testing.T) {
+		started := make(chan struct{})
+		cancelled := make(chan error, 1)
+		results := make(chan string, 10)
+
+		userID := NewSignal(1)
+		user := NewAsyncComputedContext(func(ctx context.Context) (string, error) {
+			id := userID.Read()
+			if id == 1 {
+				close(started)
+				<-ctx.Done()
+				cancelled <- ctx.Err()
+				return "user 1", nil // late result, should be discarded
+			}
+
+			return fmt.Sprintf("user %d", id), nil
+		})
+
+		NewEffect(func() {
+			u, _ := user.Read()
+			results <- u
+		})
+
+		<-started
+		userID.Write(2)
+
+		assert.ErrorIs(t, <-cancelled, context.Canceled)
+		assert.Equal(t, "user 2", <-results)
+		assert.Never(t, func() bool { return len(results) > 0 }, 50*time.Millisecond, 5*time.Millisecond)
+	})
+
+	t.Run("stale runs stop tracking dependencies", func(t *testing.T) {
+		var runs atomic.Int64
+		started := make(chan struct{})
+		stale := make(chan struct{})
+		results := make(chan string, 10)
+
+		userID := NewSignal(1)
+		filter := NewSignal("")
+		user := NewAsyncComputedContext(func(ctx context.Context) (string, error) {
+			runs.Add(1)
+
+			id := userID.Read()
+			if id == 1 {
+				close(started)
+				<-ctx.Done()
+
+				// read once the node has recomputed, it must not become a dependency
+				filter.Read()
+				close(stale)
+			}
+
+			return fmt.Sprintf("user %d", id), nil
+		})
+
+		NewEffect(func() {
+			if u, _ := user.Read(); u != "" {
+				results <- u
+			}
+		})
+
+		<-started
+		userID.Write(2)
+		assert.Equal(t, "user 2", <-results)
+
+		<-stale
+		filter.Write("admins")
+
+		assert.Never(t, func() bool { return runs.Load() > 2 }, 50*time.Millisecond, 5*time.Millisecond)
+	})
+
+	t.Run("cancels when owner is disposed", func(t *testing.T) {
+		started := make(chan struct{})
+		cancelled := make(chan error, 1)
+
+		o := NewOwner()
+		o.Run(func() error {
+			NewAsyncComputedContext(func(ctx context.Context) (string, error) {
+				close(started)
+				<-ctx.Done()
+				cancelled <- ctx.Err()
+				return "", ctx.Err()
+			})
+
+			return nil
+		})
+
+		<-started
+		o.Dispose()
+
+		assert.ErrorIs(t, <-cancelled, context.Canceled)
+	})
+
+	t.Run("context is done once resolved", func(t *testing.T) {
+		contexts := make(chan context.Context, 1)
+		done := make(chan struct{})
+
+		user := NewAsyncComputedContext(func(ctx context.Context) (string, error) {
+			contexts <- ctx
+			return "bob", nil
+		})
+
+		NewEffect(func() {
+			user.Read()
+			close(done)
+		})
+
+		<-done
+		assert.ErrorIs(t, (<-contexts).Err(), context.Canceled)
+	})
+}
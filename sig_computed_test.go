@@ -54,7 +54,8 @@ func TestComputed(t *testing.T) {
 		a.Read()
 		b.Read()
 
-		count.Write(10) // should recompute a but not b since a's value didn't change
+		count.Write(10)
+		b.Read() // should recompute a but not b since a's value didn't change
 
 		assert.Equal(t, []string{
 			"running a",
@@ -82,7 +83,9 @@ func TestComputed(t *testing.T) {
 
 		count.Write(2) // shouldn't propagate
 		count.Write(3)
+		double.Read()
 		count.Write(4) // shouldn't propagate
+		double.Read()
 
 		assert.Equal(t, []string{
 			"computing double",
@@ -121,4 +124,205 @@ func TestComputed(t *testing.T) {
 		// TODO: define expected behavior
 		_ = log
 	})
+
+	t.Run("recomputes lazily when not observed", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+		double := NewComputed(func() int {
+			log = append(log, "computing double")
+			return count.Read() * 2
+		})
+
+		count.Write(2)
+		count.Write(3)
+		log = append(log, "written")
+
+		assert.Equal(t, 6, double.Read())
+		assert.Equal(t, 6, double.Read())
+
+		assert.Equal(t, []string{
+			"computing double",
+			"written",
+			"computing double",
+		}, log)
+	})
+
+	t.Run("recomputes eagerly when observed by an effect", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+		double := NewComputed(func() int {
+			log = append(log, "computing double")
+			return count.Read() * 2
+		})
+		idle := NewComputed(func() int {
+			log = append(log, "computing idle")
+			return count.Read() * 3
+		})
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("double %d", double.Read()))
+		})
+
+		count.Write(2)
+		log = append(log, "written")
+
+		assert.Equal(t, 6, idle.Read())
+
+		assert.Equal(t, []string{
+			"computing double",
+			"computing idle",
+			"double 2",
+			"computing double",
+			"double 4",
+			"written",
+			"computing idle",
+		}, log)
+	})
+
+	t.Run("deep dependency chain", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(0)
+
+		chain := NewComputed(func() int { return count.Read() })
+		for range 10_000 {
+			prev := chain
+			chain = NewComputed(func() int { return prev.Read() + 1 })
+		}
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("changed %d", chain.Read()))
+		})
+
+		count.Write(10)
+		count.Write(20)
+
+		assert.Equal(t, []string{
+			"changed 10000",
+			"changed 10010",
+			"changed 10020",
+		}, log)
+	})
+}
+
+func TestComputedOptions(t *testing.T) {
+	t.Run("custom equality", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+		parity := NewComputed(func() int {
+			return count.Read()
+		}, ComputedOptions[int]{
+			Equals: func(a, b int) bool { return a%2 == b%2 },
+		})
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("parity %d", parity.Read()%2))
+		})
+
+		count.Write(3)
+		count.Write(4)
+
+		assert.Equal(t, []string{
+			"parity 1",
+			"parity 0",
+		}, log)
+	})
+
+	t.Run("always propagate", func(t *testing.T) {
+		type user struct{ name string }
+		log := []string{}
+
+		name := NewSignal("bob")
+		other := NewSignal(0)
+		current := NewComputed(func() *user {
+			other.Read()
+			return &user{name.Read()}
+		}, ComputedOptions[*user]{
+			Equals: NeverEqual[*user],
+		})
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("user %s", current.Read().name))
+		})
+
+		other.Write(1)
+
+		assert.Equal(t, []string{
+			"user bob",
+			"user bob",
+		}, log)
+	})
+
+	t.Run("initial value", func(t *testing.T) {
+		gate := make(chan struct{})
+		defer close(gate)
+
+		user := NewAsyncComputed(func() (string, error) {
+			<-gate
+			return "bob", nil
+		})
+
+		name := NewComputed(func() string {
+			u, _ := user.Read()
+			return u
+		}, ComputedOptions[string]{
+			Initial: "loading",
+		})
+
+		assert.Equal(t, "loading", name.Read())
+	})
+
+	t.Run("custom equality is not called with the initial value", func(t *testing.T) {
+		type user struct{ id int }
+
+		current := NewSignal(&user{1})
+		same := NewComputed(func() *user {
+			return current.Read()
+		}, ComputedOptions[*user]{
+			Equals: func(a, b *user) bool { return a.id == b.id },
+		})
+
+		assert.Equal(t, 1, same.Read().id)
+
+		current.Write(&user{2})
+		assert.Equal(t, 2, same.Read().id)
+	})
+
+	t.Run("custom equality with a pending first computation", func(t *testing.T) {
+		type user struct{ id int }
+		gate := make(chan struct{})
+		done := make(chan int)
+
+		async := NewAsyncComputed(func() (*user, error) {
+			<-gate
+			return &user{1}, nil
+		})
+
+		current := NewComputed(func() *user {
+			u, _ := async.Read()
+			return u
+		}, ComputedOptions[*user]{
+			Equals: func(a, b *user) bool { return a.id == b.id },
+		})
+
+		NewEffect(func() {
+			if u := current.Read(); u != nil {
+				done <- u.id
+			}
+		})
+
+		close(gate)
+		assert.Equal(t, 1, <-done)
+	})
+
+	t.Run("name", func(t *testing.T) {
+		named := NewComputed(func() int { return 1 }, ComputedOptions[int]{Name: "named"})
+		unnamed := NewComputed(func() int { return 1 })
+
+		assert.Equal(t, "named", named.Name())
+		assert.Equal(t, "", unnamed.Name())
+	})
 }
@@ -0,0 +1,77 @@
+package sig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDerivedSignal(t *testing.T) {
+	t.Run("derives value from signal", func(t *testing.T) {
+		server := NewSignal("bob")
+		name := NewDerivedSignal(func(string) string { return server.Read() })
+
+		assert.Equal(t, "bob", name.Read())
+
+		server.Write("alice")
+		assert.Equal(t, "alice", name.Read())
+	})
+
+	t.Run("writes override until dependencies change", func(t *testing.T) {
+		log := []string{}
+
+		server := NewSignal("bob")
+		name := NewDerivedSignal(func(string) string { return server.Read() })
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("name %s", name.Read()))
+		})
+
+		name.Write("edited")
+		assert.Equal(t, "edited", name.Read())
+
+		server.Write("alice")
+		assert.Equal(t, "alice", name.Read())
+
+		assert.Equal(t, []string{
+			"name bob",
+			"name edited",
+			"name alice",
+		}, log)
+	})
+
+	t.Run("write overrides pending dependency changes", func(t *testing.T) {
+		server := NewSignal("bob")
+		name := NewDerivedSignal(func(string) string { return server.Read() })
+
+		NewBatch(func() {
+			server.Write("alice")
+			name.Write("edited")
+		})
+
+		assert.Equal(t, "edited", name.Read())
+	})
+
+	t.Run("derives from the previous value", func(t *testing.T) {
+		step := NewSignal(1)
+		total := NewDerivedSignal(func(prev int) int { return prev + step.Read() })
+
+		assert.Equal(t, 1, total.Read())
+
+		total.Write(10)
+		step.Write(2)
+		assert.Equal(t, 12, total.Read())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		count := NewSignal(1)
+		double := NewDerivedSignal(func(int) int { return count.Read() * 2 })
+
+		double.Update(func(d int) int { return d + 1 })
+		assert.Equal(t, 3, double.Peek())
+
+		count.Write(2)
+		assert.Equal(t, 4, double.Peek())
+	})
+}
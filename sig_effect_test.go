@@ -277,3 +277,431 @@ func TestRenderEffect(t *testing.T) {
 		}, log)
 	})
 }
+
+func TestEffectHandle(t *testing.T) {
+	t.Run("disposes a single effect", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(0)
+
+		a := NewEffect(func() {
+			log = append(log, fmt.Sprintf("a %d", count.Read()))
+			OnCleanup(func() { log = append(log, "cleanup a") })
+		})
+		b := NewRenderEffect(func() {
+			log = append(log, fmt.Sprintf("b %d", count.Read()))
+		})
+
+		a.Dispose()
+		count.Write(10)
+
+		assert.True(t, a.IsDisposed())
+		assert.False(t, b.IsDisposed())
+		assert.Equal(t, []string{
+			"a 0",
+			"b 0",
+			"cleanup a",
+			"b 10",
+		}, log)
+	})
+
+	t.Run("disposed along with its owner", func(t *testing.T) {
+		o := NewOwner()
+
+		var effect *Effect
+		o.Run(func() error {
+			effect = NewEffect(func() {})
+			return nil
+		})
+
+		o.Dispose()
+
+		assert.True(t, effect.IsDisposed())
+	})
+
+	t.Run("owner of the effect", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(0)
+		effect := NewEffect(func() { count.Read() })
+
+		effect.Owner().Run(func() error {
+			NewEffect(func() {
+				log = append(log, fmt.Sprintf("nested %d", count.Read()))
+			})
+			return nil
+		})
+		effect.Owner().OnCleanup(func() { log = append(log, "cleanup") })
+
+		effect.Dispose()
+		count.Write(10)
+
+		assert.Equal(t, []string{
+			"nested 0",
+			"cleanup",
+		}, log)
+	})
+
+	t.Run("disposing twice", func(t *testing.T) {
+		log := []string{}
+
+		o := NewOwner()
+		o.Run(func() error {
+			first := NewEffect(func() {
+				OnCleanup(func() { log = append(log, "cleanup first") })
+			})
+			NewEffect(func() {
+				OnCleanup(func() { log = append(log, "cleanup second") })
+			})
+
+			first.Dispose()
+			first.Dispose()
+			return nil
+		})
+
+		o.Dispose()
+
+		assert.Equal(t, []string{
+			"cleanup first",
+			"cleanup second",
+		}, log)
+	})
+}
+
+func TestEffectPause(t *testing.T) {
+	t.Run("runs once resumed", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(0)
+		effect := NewEffect(func() {
+			log = append(log, fmt.Sprintf("changed %d", count.Read()))
+			OnCleanup(func() { log = append(log, "cleanup") })
+		})
+
+		effect.Pause()
+		count.Write(10)
+		count.Write(20)
+		log = append(log, "resuming")
+		effect.Resume()
+
+		assert.Equal(t, []string{
+			"changed 0",
+			"resuming",
+			"cleanup",
+			"changed 20",
+		}, log)
+	})
+
+	t.Run("does not run when nothing changed", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(0)
+		effect := NewEffect(func() {
+			log = append(log, fmt.Sprintf("changed %d", count.Read()))
+		})
+
+		effect.Pause()
+		effect.Resume()
+
+		assert.Equal(t, []string{
+			"changed 0",
+		}, log)
+	})
+
+	t.Run("does not run when dependencies are unchanged", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(0)
+		parity := NewComputed(func() int { return count.Read() % 2 })
+
+		effect := NewEffect(func() {
+			log = append(log, fmt.Sprintf("parity %d", parity.Read()))
+		})
+
+		effect.Pause()
+		count.Write(2)
+		effect.Resume()
+
+		count.Write(3)
+
+		assert.Equal(t, []string{
+			"parity 0",
+			"parity 1",
+		}, log)
+	})
+
+	t.Run("paused while scheduled", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(0)
+
+		var effect *Effect
+		NewRenderEffect(func() {
+			if count.Read() > 0 {
+				effect.Pause()
+			}
+		})
+
+		effect = NewEffect(func() {
+			log = append(log, fmt.Sprintf("changed %d", count.Read()))
+		})
+
+		count.Write(10)
+		log = append(log, "resuming")
+		effect.Resume()
+
+		assert.Equal(t, []string{
+			"changed 0",
+			"resuming",
+			"changed 10",
+		}, log)
+	})
+}
+
+func TestEffectWith(t *testing.T) {
+	t.Run("applies computed values", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+
+		NewEffectWith(func(prev int) int {
+			log = append(log, fmt.Sprintf("compute %d", prev))
+			return count.Read() * 2
+		}, func(next, prev int) func() {
+			log = append(log, fmt.Sprintf("apply %d %d", next, prev))
+			return func() { log = append(log, fmt.Sprintf("cleanup %d", next)) }
+		})
+
+		count.Write(10)
+
+		assert.Equal(t, []string{
+			"compute 0",
+			"apply 2 0",
+			"compute 2",
+			"cleanup 2",
+			"apply 20 2",
+		}, log)
+	})
+
+	t.Run("does not track the apply phase", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+		other := NewSignal(1)
+
+		NewEffectWith(func(int) int {
+			return count.Read()
+		}, func(next, _ int) func() {
+			log = append(log, fmt.Sprintf("apply %d %d", next, other.Read()))
+			return nil
+		})
+
+		other.Write(2)
+		count.Write(2)
+
+		assert.Equal(t, []string{
+			"apply 1 1",
+			"apply 2 2",
+		}, log)
+	})
+
+	t.Run("skips apply when the value is unchanged", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+
+		NewEffectWith(func(int) int {
+			return count.Read() % 2
+		}, func(next, prev int) func() {
+			log = append(log, fmt.Sprintf("apply %d %d", next, prev))
+			return nil
+		})
+
+		count.Write(3)
+		count.Write(4)
+
+		assert.Equal(t, []string{
+			"apply 1 0",
+			"apply 0 1",
+		}, log)
+	})
+
+	t.Run("runs after render effects", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(0)
+
+		NewEffectWith(func(int) int {
+			log = append(log, "compute")
+			return count.Read()
+		}, func(int, int) func() {
+			log = append(log, "apply")
+			return nil
+		})
+
+		NewRenderEffect(func() {
+			count.Read()
+			log = append(log, "render")
+		})
+
+		count.Write(10)
+
+		assert.Equal(t, []string{
+			"compute",
+			"apply",
+			"render",
+			"compute",
+			"render",
+			"apply",
+		}, log)
+	})
+
+	t.Run("cleans up on dispose", func(t *testing.T) {
+		log := []string{}
+
+		effect := NewEffectWith(func(int) int {
+			return 1
+		}, func(int, int) func() {
+			return func() { log = append(log, "cleanup") }
+		})
+
+		effect.Dispose()
+
+		assert.Equal(t, []string{
+			"cleanup",
+		}, log)
+	})
+}
+
+func TestOn(t *testing.T) {
+	t.Run("runs with current and previous values", func(t *testing.T) {
+		log := []string{}
+
+		a := NewSignal(1)
+		b := NewSignal(2)
+
+		On(func(values, prev []any) {
+			log = append(log, fmt.Sprintf("values %v prev %v", values, prev))
+		}, a, b)
+
+		a.Write(10)
+		b.Write(20)
+
+		assert.Equal(t, []string{
+			"values [1 2] prev []",
+			"values [10 2] prev [1 2]",
+			"values [10 20] prev [10 2]",
+		}, log)
+	})
+
+	t.Run("depends on values of different types", func(t *testing.T) {
+		log := []string{}
+
+		id := NewSignal(1)
+		name := NewSignal("bob")
+		label := NewComputed(func() string { return fmt.Sprintf("%s#%d", name.Read(), id.Read()) })
+
+		On(func(values, prev []any) {
+			log = append(log, fmt.Sprintf("id %d name %s label %s prev %v", values[0].(int), values[1].(string), values[2].(string), prev))
+		}, id, name, label)
+
+		name.Write("alice")
+
+		assert.Equal(t, []string{
+			"id 1 name bob label bob#1 prev []",
+			"id 1 name alice label alice#1 prev [1 bob bob#1]",
+		}, log)
+	})
+
+	t.Run("depends on any readable", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+
+		On(func(values, prev []any) {
+			log = append(log, fmt.Sprintf("count %v", values[0]))
+		}, AsDependency(Readable[int](count)))
+
+		count.Write(2)
+
+		assert.Equal(t, []string{
+			"count 1",
+			"count 2",
+		}, log)
+	})
+
+	t.Run("does not track reads within the function", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+		other := NewSignal(1)
+
+		On(func(values, prev []any) {
+			log = append(log, fmt.Sprintf("count %d other %d", values[0], other.Read()))
+		}, count)
+
+		other.Write(2)
+		count.Write(2)
+
+		assert.Equal(t, []string{
+			"count 1 other 1",
+			"count 2 other 2",
+		}, log)
+	})
+
+	t.Run("defers the first run", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+
+		OnWithOptions(OnOptions{Defer: true}, func(values, prev []any) {
+			log = append(log, fmt.Sprintf("count %v prev %v", values, prev))
+		}, count)
+
+		assert.Equal(t, []string{}, log)
+
+		count.Write(2)
+
+		assert.Equal(t, []string{
+			"count [2] prev [1]",
+		}, log)
+	})
+
+	t.Run("depends on computeds", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+		double := NewComputed(func() int { return count.Read() * 2 })
+
+		effect := On(func(values, prev []any) {
+			log = append(log, fmt.Sprintf("double %d", values[0]))
+		}, double)
+
+		count.Write(2)
+		effect.Dispose()
+		count.Write(3)
+
+		assert.Equal(t, []string{
+			"double 2",
+			"double 4",
+		}, log)
+	})
+
+	t.Run("owns nodes created within the function", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+
+		On(func(values, prev []any) {
+			OnCleanup(func() {
+				log = append(log, fmt.Sprintf("cleanup %d", values[0]))
+			})
+		}, count)
+
+		count.Write(2)
+
+		assert.Equal(t, []string{
+			"cleanup 1",
+		}, log)
+	})
+}
@@ -0,0 +1,165 @@
+package sig
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoop(t *testing.T) {
+	t.Run("runs dispatched functions from any goroutine", func(t *testing.T) {
+		var wg sync.WaitGroup
+		runs := 0
+
+		loop := NewLoop()
+		defer loop.Stop()
+
+		var count *Signal[int]
+		loop.DispatchSync(func() {
+			count = NewSignal(0)
+
+			NewEffect(func() {
+				count.Read()
+				runs++
+			})
+		})
+
+		for range 100 {
+			wg.Go(func() {
+				loop.Dispatch(func() {
+					count.Write(Untrack(count.Read) + 1)
+				})
+			})
+		}
+		wg.Wait()
+
+		var total int
+		loop.DispatchSync(func() { total = count.Read() })
+
+		assert.Equal(t, 100, total)
+		assert.Equal(t, 101, runs)
+	})
+
+	t.Run("flushes each function as a batch", func(t *testing.T) {
+		log := []string{}
+
+		loop := NewLoop()
+		defer loop.Stop()
+
+		var count *Signal[int]
+		loop.DispatchSync(func() {
+			count = NewSignal(0)
+
+			NewEffect(func() {
+				log = append(log, fmt.Sprintf("changed %d", count.Read()))
+			})
+		})
+
+		loop.DispatchSync(func() {
+			count.Write(10)
+			count.Write(20)
+			log = append(log, "written")
+		})
+
+		assert.Equal(t, []string{
+			"changed 0",
+			"written",
+			"changed 20",
+		}, log)
+	})
+
+	t.Run("returns panics as errors", func(t *testing.T) {
+		loop := NewLoop()
+		defer loop.Stop()
+
+		err := loop.DispatchSync(func() { panic("boom") })
+		assert.EqualError(t, err, "dispatched function panicked: boom")
+
+		// the loop keeps running
+		assert.NoError(t, loop.DispatchSync(func() {}))
+	})
+
+	t.Run("dispatches synchronously from the loop", func(t *testing.T) {
+		log := []string{}
+
+		loop := NewLoop()
+		defer loop.Stop()
+
+		loop.DispatchSync(func() {
+			loop.DispatchSync(func() { log = append(log, "inner") })
+			log = append(log, "outer")
+		})
+
+		assert.Equal(t, []string{
+			"inner",
+			"outer",
+		}, log)
+	})
+
+	t.Run("runs writes from other goroutines on the loop", func(t *testing.T) {
+		loop := NewLoop()
+		defer loop.Stop()
+
+		var loopID string
+		loop.DispatchSync(func() { loopID = goroutineID() })
+
+		ran := make(chan string, 1)
+
+		var count *Signal[int]
+		loop.DispatchSync(func() {
+			count = NewSignal(0)
+
+			NewEffect(func() {
+				if count.Read() == 0 {
+					return
+				}
+
+				// would deadlock if the effect ran on the writer's goroutine
+				loop.DispatchSync(func() {})
+				ran <- goroutineID()
+			})
+		})
+
+		go count.Write(1)
+
+		select {
+		case id := <-ran:
+			assert.Equal(t, loopID, id)
+		case <-time.After(time.Second):
+			t.Fatal("the effect did not run")
+		}
+	})
+
+	t.Run("stop runs queued functions and disposes owners", func(t *testing.T) {
+		log := []string{}
+
+		loop := NewLoop()
+
+		loop.Dispatch(func() {
+			NewEffect(func() {
+				OnCleanup(func() { log = append(log, "cleanup") })
+			})
+		})
+		loop.Dispatch(func() { log = append(log, "queued") })
+
+		loop.Stop()
+
+		assert.ErrorIs(t, loop.DispatchSync(func() {}), ErrLoopStopped)
+		assert.Equal(t, []string{
+			"queued",
+			"cleanup",
+		}, log)
+	})
+}
+
+// goroutineID returns the id of the current goroutine, from its stack trace.
+func goroutineID() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	return string(bytes.Fields(buf)[1])
+}
@@ -0,0 +1,198 @@
+package sig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("get and set", func(t *testing.T) {
+		users := NewMap[int, string]()
+
+		assert.Equal(t, "", users.Get(1))
+		assert.False(t, users.Has(1))
+
+		users.Set(1, "bob")
+		assert.Equal(t, "bob", users.Get(1))
+		assert.True(t, users.Has(1))
+		assert.Equal(t, 1, users.Len())
+	})
+
+	t.Run("only updates readers of the written key", func(t *testing.T) {
+		log := []string{}
+
+		users := NewMap[int, string]()
+		users.Set(1, "bob")
+		users.Set(2, "alice")
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("user 1 %s", users.Get(1)))
+		})
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("user 2 %s", users.Get(2)))
+		})
+
+		users.Set(2, "alicia")
+		users.Set(3, "carol")
+
+		assert.Equal(t, []string{
+			"user 1 bob",
+			"user 2 alice",
+			"user 2 alicia",
+		}, log)
+	})
+
+	t.Run("ignores writes of the same value", func(t *testing.T) {
+		log := []string{}
+
+		users := NewMap[int, string]()
+		users.Set(1, "bob")
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("user %s", users.Get(1)))
+		})
+
+		users.Set(1, "bob")
+
+		assert.Equal(t, []string{
+			"user bob",
+		}, log)
+	})
+
+	t.Run("has tracks the key", func(t *testing.T) {
+		log := []string{}
+
+		users := NewMap[int, string]()
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("has %t", users.Has(1)))
+		})
+
+		users.Set(2, "alice")
+		users.Set(1, "bob")
+		users.Delete(1)
+
+		assert.Equal(t, []string{
+			"has false",
+			"has true",
+			"has false",
+		}, log)
+	})
+
+	t.Run("len and keys track the structure", func(t *testing.T) {
+		log := []string{}
+
+		users := NewMap[int, string]()
+		users.Set(1, "bob")
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("len %d keys %v", users.Len(), users.Keys()))
+		})
+
+		users.Set(1, "robert")
+		users.Set(2, "alice")
+		users.Delete(1)
+		users.Delete(3)
+
+		assert.Equal(t, []string{
+			"len 1 keys [1]",
+			"len 2 keys [1 2]",
+			"len 1 keys [2]",
+		}, log)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		log := []string{}
+
+		users := NewMap[int, string]()
+		users.Set(2, "alice")
+		users.Set(1, "bob")
+
+		NewEffect(func() {
+			entries := []string{}
+			for id, name := range users.All() {
+				entries = append(entries, fmt.Sprintf("%d:%s", id, name))
+			}
+			log = append(log, fmt.Sprint(entries))
+		})
+
+		users.Set(1, "robert")
+		users.Set(3, "carol")
+
+		assert.Equal(t, []string{
+			"[2:alice 1:bob]",
+			"[2:alice 1:robert]",
+			"[2:alice 1:robert 3:carol]",
+		}, log)
+	})
+
+	t.Run("batches writes", func(t *testing.T) {
+		log := []string{}
+
+		users := NewMap[int, string]()
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("len %d", users.Len()))
+		})
+
+		NewBatch(func() {
+			users.Set(1, "bob")
+			users.Set(2, "alice")
+		})
+
+		assert.Equal(t, []string{
+			"len 0",
+			"len 2",
+		}, log)
+	})
+	t.Run("keeps insertion order across deletes", func(t *testing.T) {
+		users := NewMap[int, string]()
+		for id := range 10 {
+			users.Set(id, fmt.Sprint(id))
+		}
+
+		for id := range 8 {
+			users.Delete(id)
+		}
+		users.Set(0, "0")
+
+		assert.Equal(t, []int{8, 9, 0}, users.Keys())
+	})
+
+	t.Run("only keeps signals for tracked keys", func(t *testing.T) {
+		log := []string{}
+
+		users := NewMap[int, string]()
+		users.Set(1, "bob")
+
+		for id := range 100 {
+			users.Get(id)
+			users.Has(id)
+		}
+		assert.Equal(t, 0, users.collection.TrackedKeys())
+
+		o := NewOwner()
+		o.Run(func() error {
+			NewEffect(func() {
+				log = append(log, fmt.Sprintf("user 1 %s", users.Get(1)))
+			})
+			return nil
+		})
+		assert.Equal(t, 1, users.collection.TrackedKeys())
+
+		users.Set(1, "robert")
+		users.Set(1, "bobby")
+		assert.Equal(t, 1, users.collection.TrackedKeys())
+
+		o.Dispose()
+		assert.Equal(t, 0, users.collection.TrackedKeys())
+
+		assert.Equal(t, []string{
+			"user 1 bob",
+			"user 1 robert",
+			"user 1 bobby",
+		}, log)
+	})
+}
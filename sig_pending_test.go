@@ -0,0 +1,205 @@
+package sig
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPending(t *testing.T) {
+	t.Run("reports unresolved async values", func(t *testing.T) {
+		gate := make(chan struct{})
+		done := make(chan struct{})
+
+		user := NewAsyncComputed(func() (string, error) {
+			<-gate
+			return "bob", nil
+		})
+
+		NewEffect(func() {
+			user.Read()
+			close(done)
+		})
+
+		assert.True(t, IsPending(func() { user.Read() }))
+
+		close(gate)
+		<-done
+
+		assert.False(t, IsPending(func() { user.Read() }))
+	})
+
+	t.Run("returns an error outside of reactive contexts", func(t *testing.T) {
+		gate := make(chan struct{})
+		done := make(chan struct{})
+
+		user := NewAsyncComputed(func() (string, error) {
+			<-gate
+			return "bob", nil
+		})
+
+		NewEffect(func() {
+			user.Read()
+			close(done)
+		})
+
+		_, err := user.Read()
+		assert.ErrorIs(t, err, ErrPending)
+
+		close(gate)
+		<-done
+
+		u, err := user.Read()
+		assert.Equal(t, "bob", u)
+		assert.NoError(t, err)
+	})
+
+	t.Run("defers effects", func(t *testing.T) {
+		var mu sync.Mutex
+		log := []string{}
+		done := make(chan struct{})
+
+		user := NewAsyncComputed(func() (string, error) {
+			return "bob", nil
+		})
+
+		NewEffect(func() {
+			u, _ := user.Read()
+
+			mu.Lock()
+			log = append(log, fmt.Sprintf("user %s", u))
+			mu.Unlock()
+
+			close(done)
+		})
+
+		<-done
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{
+			"user bob",
+		}, log)
+	})
+
+	t.Run("propagates through computeds", func(t *testing.T) {
+		var mu sync.Mutex
+		log := []string{}
+		gate := make(chan struct{})
+		done := make(chan struct{})
+
+		user := NewAsyncComputed(func() (string, error) {
+			<-gate
+			return "bob", nil
+		})
+		greeting := NewComputed(func() string {
+			u, _ := user.Read()
+			return "hello " + u
+		})
+		shout := NewComputed(func() string {
+			return greeting.Read() + "!"
+		})
+
+		NewEffect(func() {
+			s := shout.Read()
+
+			mu.Lock()
+			log = append(log, s)
+			mu.Unlock()
+
+			close(done)
+		})
+
+		assert.True(t, IsPending(func() { greeting.Read() }))
+		assert.True(t, IsPending(func() { shout.Read() }))
+
+		close(gate)
+		<-done
+
+		assert.False(t, IsPending(func() { shout.Read() }))
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{
+			"hello bob!",
+		}, log)
+	})
+
+	t.Run("tracks within effects", func(t *testing.T) {
+		var mu sync.Mutex
+		log := []string{}
+		gates := map[int]chan struct{}{
+			1: make(chan struct{}),
+			2: make(chan struct{}),
+		}
+		results := make(chan string, 10)
+
+		userID := NewSignal(1)
+		user := NewAsyncComputed(func() (string, error) {
+			id := userID.Read()
+			<-gates[id]
+			return fmt.Sprintf("user %d", id), nil
+		})
+
+		NewEffect(func() {
+			if IsPending(func() { user.Read() }) {
+				mu.Lock()
+				log = append(log, "loading...")
+				mu.Unlock()
+				return
+			}
+
+			u, _ := user.Read()
+
+			mu.Lock()
+			log = append(log, u)
+			mu.Unlock()
+
+			results <- u
+		})
+
+		close(gates[1])
+		assert.Equal(t, "user 1", <-results)
+
+		userID.Write(2)
+		close(gates[2])
+		assert.Equal(t, "user 2", <-results)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{
+			"loading...",
+			"user 1",
+			"loading...",
+			"user 2",
+		}, log)
+	})
+
+	t.Run("async computed depending on another", func(t *testing.T) {
+		results := make(chan string, 10)
+
+		user := NewAsyncComputed(func() (string, error) {
+			return "bob", nil
+		})
+		profile := NewAsyncComputed(func() (string, error) {
+			u, err := user.Read()
+			return fmt.Sprintf("profile of %s", u), err
+		})
+
+		NewEffect(func() {
+			p, _ := profile.Read()
+			results <- p
+		})
+
+		assert.Equal(t, "profile of bob", <-results)
+	})
+
+	t.Run("false for resolved values", func(t *testing.T) {
+		count := NewSignal(1)
+		double := NewComputed(func() int { return count.Read() * 2 })
+
+		assert.False(t, IsPending(func() { double.Read() }))
+	})
+}
@@ -0,0 +1,95 @@
+package sig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ Readable[int] = (*Signal[int])(nil)
+	_ Readable[int] = (*Computed[int])(nil)
+	_ Readable[int] = (*DerivedSignal[int])(nil)
+	_ Readable[int] = (*Context[int])(nil)
+
+	_ Writable[int] = (*Signal[int])(nil)
+	_ Writable[int] = (*DerivedSignal[int])(nil)
+
+	_ Dependency = (*Signal[int])(nil)
+	_ Dependency = (*Computed[int])(nil)
+	_ Dependency = (*DerivedSignal[int])(nil)
+	_ Dependency = (*Context[int])(nil)
+	_ Dependency = asyncReadable[int]{}
+)
+
+func TestReadable(t *testing.T) {
+	describe := func(r Readable[int]) string {
+		return fmt.Sprintf("value %d", r.Read())
+	}
+
+	t.Run("accepts any reactive value", func(t *testing.T) {
+		count := NewSignal(1)
+		double := NewComputed(func() int { return count.Read() * 2 })
+		derived := NewDerivedSignal(func(int) int { return count.Read() * 3 })
+		ctx := NewContext(4)
+
+		assert.Equal(t, "value 1", describe(count))
+		assert.Equal(t, "value 2", describe(double))
+		assert.Equal(t, "value 3", describe(derived))
+		assert.Equal(t, "value 4", describe(ctx))
+	})
+
+	t.Run("tracks reads", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(1)
+
+		NewEffect(func() {
+			log = append(log, describe(count))
+		})
+
+		count.Write(2)
+
+		assert.Equal(t, []string{
+			"value 1",
+			"value 2",
+		}, log)
+	})
+
+	t.Run("async computeds", func(t *testing.T) {
+		done := make(chan struct{})
+
+		user := NewAsyncComputed(func() (int, error) {
+			return 1, nil
+		})
+
+		readable := user.Readable()
+
+		NewEffect(func() {
+			if readable.Read() == 1 {
+				close(done)
+			}
+		})
+
+		<-done
+		assert.Equal(t, 1, readable.Peek())
+	})
+}
+
+func TestWritable(t *testing.T) {
+	increment := func(w Writable[int]) {
+		w.Update(func(v int) int { return v + 1 })
+	}
+
+	t.Run("accepts any writable value", func(t *testing.T) {
+		count := NewSignal(1)
+		derived := NewDerivedSignal(func(int) int { return count.Read() * 10 })
+
+		increment(count)
+		increment(derived)
+
+		assert.Equal(t, 2, count.Peek())
+		assert.Equal(t, 21, derived.Peek())
+	})
+}
@@ -0,0 +1,385 @@
+package sig
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AnatoleLucet/sig/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntime(t *testing.T) {
+	t.Run("runs nodes from any goroutine", func(t *testing.T) {
+		var wg sync.WaitGroup
+		log := []string{}
+
+		rt := NewRuntime()
+
+		var count *Signal[int]
+		rt.Run(func() {
+			count = NewSignal(0)
+
+			NewEffect(func() {
+				log = append(log, fmt.Sprintf("changed %d", count.Read()))
+			})
+		})
+
+		wg.Go(func() {
+			rt.Run(func() { count.Write(10) })
+		})
+		wg.Wait()
+
+		wg.Go(func() {
+			rt.Run(func() { count.Write(20) })
+		})
+		wg.Wait()
+
+		assert.Equal(t, []string{
+			"changed 0",
+			"changed 10",
+			"changed 20",
+		}, log)
+	})
+
+	t.Run("serializes concurrent runs", func(t *testing.T) {
+		var wg sync.WaitGroup
+		runs := 0
+
+		rt := NewRuntime()
+
+		var count *Signal[int]
+		rt.Run(func() {
+			count = NewSignal(0)
+
+			NewEffect(func() {
+				count.Read()
+				runs++
+			})
+		})
+
+		for range 100 {
+			wg.Go(func() {
+				rt.Run(func() {
+					count.Write(Untrack(count.Read) + 1)
+				})
+			})
+		}
+		wg.Wait()
+
+		rt.Run(func() {
+			assert.Equal(t, 100, count.Read())
+		})
+		assert.Equal(t, 101, runs)
+	})
+
+	t.Run("is isolated from the goroutine's runtime", func(t *testing.T) {
+		log := []string{}
+
+		rt := NewRuntime()
+
+		OnSettled(func() { log = append(log, "goroutine settled") })
+		rt.OnSettled(func() { log = append(log, "runtime settled") })
+
+		rt.Run(func() {
+			count := NewSignal(0)
+			NewEffect(func() { count.Read() })
+			count.Write(10)
+		})
+
+		assert.Equal(t, []string{
+			"runtime settled",
+		}, log)
+	})
+
+	t.Run("current runtime", func(t *testing.T) {
+		log := []string{}
+
+		rt := NewRuntime()
+		rt.OnSettled(func() { log = append(log, "settled") })
+
+		rt.Run(func() {
+			CurrentRuntime().Flush()
+		})
+
+		assert.Equal(t, []string{
+			"settled",
+		}, log)
+	})
+
+	t.Run("flush within a batch", func(t *testing.T) {
+		log := []string{}
+
+		rt := NewRuntime()
+		rt.Run(func() {
+			count := NewSignal(0)
+
+			NewEffect(func() {
+				log = append(log, fmt.Sprintf("changed %d", count.Read()))
+			})
+
+			NewBatch(func() {
+				count.Write(10)
+				log = append(log, "written")
+
+				rt.Flush()
+				log = append(log, "flushed")
+			})
+		})
+
+		assert.Equal(t, []string{
+			"changed 0",
+			"written",
+			"changed 10",
+			"flushed",
+		}, log)
+	})
+
+	t.Run("methods run within the runtime", func(t *testing.T) {
+		log := []string{}
+
+		rt := NewRuntime()
+
+		var count *Signal[int]
+		rt.Run(func() { count = NewSignal(0) })
+
+		rt.NewEffect(func() {
+			log = append(log, fmt.Sprintf("changed %d", count.Read()))
+		})
+
+		rt.NewBatch(func() {
+			count.Write(10)
+			count.Write(20)
+		})
+
+		assert.Equal(t, []string{
+			"changed 0",
+			"changed 20",
+		}, log)
+	})
+
+	t.Run("owners are not created within another goroutine's run", func(t *testing.T) {
+		log := []string{}
+
+		rt := NewRuntime()
+
+		entered := make(chan struct{})
+		created := make(chan *Owner)
+
+		go func() {
+			<-entered
+			created <- rt.NewOwner()
+		}()
+
+		var parent *Owner
+		rt.Run(func() {
+			parent = NewOwner()
+			parent.Run(func() error {
+				close(entered)
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			})
+		})
+
+		owner := <-created
+		owner.OnCleanup(func() { log = append(log, "disposed") })
+		parent.Dispose()
+
+		assert.Equal(t, []string{}, log)
+	})
+}
+
+func TestDisposeRuntime(t *testing.T) {
+	t.Run("disposes root owners", func(t *testing.T) {
+		var wg sync.WaitGroup
+		log := []string{}
+
+		count := NewSignal(0)
+
+		wg.Go(func() {
+			NewEffect(func() {
+				log = append(log, fmt.Sprintf("changed %d", count.Read()))
+				OnCleanup(func() { log = append(log, "cleanup") })
+			})
+
+			o := NewOwner()
+			o.OnCleanup(func() { log = append(log, "owner cleanup") })
+
+			DisposeRuntime()
+
+			// should not trigger the effect
+			count.Write(10)
+		})
+
+		wg.Wait()
+
+		assert.Equal(t, []string{
+			"changed 0",
+			"owner cleanup",
+			"cleanup",
+		}, log)
+	})
+
+	t.Run("drops pending callbacks", func(t *testing.T) {
+		var wg sync.WaitGroup
+		log := []string{}
+
+		wg.Go(func() {
+			OnSettled(func() { log = append(log, "settled") })
+
+			DisposeRuntime()
+
+			NewSignal(0).Write(10)
+		})
+
+		wg.Wait()
+
+		assert.Empty(t, log)
+	})
+
+	t.Run("explicit runtime", func(t *testing.T) {
+		log := []string{}
+
+		rt := NewRuntime()
+		rt.Run(func() {
+			NewEffect(func() {
+				OnCleanup(func() { log = append(log, "cleanup") })
+			})
+		})
+
+		rt.Dispose()
+
+		assert.Equal(t, []string{
+			"cleanup",
+		}, log)
+	})
+
+	t.Run("reclaims runtimes of exited goroutines", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		count := NewSignal(0)
+
+		internal.Reclaim()
+		baseline := internal.RuntimeCount()
+
+		for range 1000 {
+			wg.Go(func() {
+				NewEffect(func() { count.Read() })
+				count.Write(1)
+			})
+		}
+
+		wg.Wait()
+
+		internal.Reclaim()
+		assert.LessOrEqual(t, internal.RuntimeCount(), baseline)
+	})
+
+	t.Run("keeps runtimes of running goroutines", func(t *testing.T) {
+		var wg sync.WaitGroup
+		ready := make(chan struct{})
+		reclaimed := make(chan struct{})
+		log := []string{}
+
+		wg.Go(func() {
+			OnSettled(func() { log = append(log, "settled") })
+			close(ready)
+
+			<-reclaimed
+			NewSignal(0).Write(10)
+		})
+
+		<-ready
+		internal.Reclaim()
+		close(reclaimed)
+
+		wg.Wait()
+
+		assert.Equal(t, []string{
+			"settled",
+		}, log)
+	})
+}
+
+func TestForeignWrites(t *testing.T) {
+	t.Run("updates subscribers within the signal's runtime", func(t *testing.T) {
+		var wg sync.WaitGroup
+		log := []string{}
+
+		count := NewSignal(0)
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("changed %d", count.Read()))
+		})
+
+		OnSettled(func() { log = append(log, "settled") })
+
+		wg.Go(func() {
+			count.Write(10)
+		})
+		wg.Wait()
+
+		assert.Equal(t, []string{
+			"changed 0",
+			"changed 10",
+			"settled",
+		}, log)
+	})
+
+	t.Run("queues writes while the runtime is busy", func(t *testing.T) {
+		var wg sync.WaitGroup
+		log := []string{}
+
+		rt := NewRuntime()
+
+		var count *Signal[int]
+		rt.Run(func() {
+			count = NewSignal(0)
+
+			NewEffect(func() {
+				log = append(log, fmt.Sprintf("changed %d", count.Read()))
+			})
+		})
+
+		rt.Run(func() {
+			// does not wait for the runtime to be released,
+			// the write is applied once it is
+			wg.Go(func() { count.Write(10) })
+			wg.Wait()
+
+			log = append(log, fmt.Sprintf("written %d", count.Read()))
+		})
+
+		assert.Equal(t, []string{
+			"changed 0",
+			"written 0",
+			"changed 10",
+		}, log)
+	})
+
+	t.Run("concurrent writers", func(t *testing.T) {
+		var wg sync.WaitGroup
+		glitches := 0
+		last := 0
+
+		count := NewSignal(0)
+		double := NewComputed(func() int { return count.Read() * 2 })
+
+		NewEffect(func() {
+			c, d := count.Read(), double.Read()
+			if d != c*2 {
+				glitches++
+			}
+			last = c
+		})
+
+		for i := range 100 {
+			wg.Go(func() { count.Write(i + 1) })
+		}
+		wg.Wait()
+
+		assert.Equal(t, 0, glitches)
+		assert.Equal(t, count.Read(), last)
+	})
+}
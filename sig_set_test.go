@@ -0,0 +1,125 @@
+package sig
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	t.Run("add, remove and toggle", func(t *testing.T) {
+		selected := NewSet(1)
+
+		assert.True(t, selected.Has(1))
+		assert.False(t, selected.Has(2))
+
+		selected.Add(2)
+		selected.Remove(1)
+		assert.Equal(t, []int{2}, slices.Collect(selected.All()))
+
+		assert.True(t, selected.Toggle(3))
+		assert.False(t, selected.Toggle(2))
+		assert.Equal(t, []int{3}, slices.Collect(selected.All()))
+		assert.Equal(t, 1, selected.Len())
+	})
+
+	t.Run("has only reruns when the membership changes", func(t *testing.T) {
+		log := []string{}
+
+		selected := NewSet[string]()
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("a %t", selected.Has("a")))
+		})
+
+		selected.Add("b")
+		selected.Add("a")
+		selected.Add("a")
+		selected.Remove("b")
+		selected.Toggle("a")
+
+		assert.Equal(t, []string{
+			"a false",
+			"a true",
+			"a false",
+		}, log)
+	})
+
+	t.Run("len and iteration track the members", func(t *testing.T) {
+		log := []string{}
+
+		selected := NewSet("a")
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("len %d members %v", selected.Len(), slices.Collect(selected.All())))
+		})
+
+		selected.Add("b")
+		selected.Add("b")
+		selected.Remove("a")
+
+		assert.Equal(t, []string{
+			"len 1 members [a]",
+			"len 2 members [a b]",
+			"len 1 members [b]",
+		}, log)
+	})
+
+	t.Run("batches updates", func(t *testing.T) {
+		log := []string{}
+
+		selected := NewSet[int]()
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("has 1 %t", selected.Has(1)))
+		})
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("len %d", selected.Len()))
+		})
+
+		NewBatch(func() {
+			for i := range 10 {
+				selected.Add(i)
+			}
+		})
+
+		assert.Equal(t, []string{
+			"has 1 false",
+			"len 0",
+			"len 10",
+			"has 1 true",
+		}, log)
+	})
+	t.Run("untracked calls do not grow the set", func(t *testing.T) {
+		selected := NewSet[int]()
+
+		for i := range 100 {
+			selected.Has(i)
+			selected.Toggle(i)
+		}
+		assert.Equal(t, 0, selected.collection.TrackedKeys())
+
+		o := NewOwner()
+		o.Run(func() error {
+			NewEffect(func() { selected.Has(1) })
+			return nil
+		})
+		assert.Equal(t, 1, selected.collection.TrackedKeys())
+
+		o.Dispose()
+		assert.Equal(t, 0, selected.collection.TrackedKeys())
+	})
+
+	t.Run("keeps insertion order across removals", func(t *testing.T) {
+		selected := NewSet(1, 2, 3, 4, 5)
+
+		selected.Remove(1)
+		selected.Remove(3)
+		selected.Toggle(4)
+		selected.Toggle(1)
+
+		assert.Equal(t, []int{2, 5, 1}, slices.Collect(selected.All()))
+	})
+}
@@ -39,4 +39,45 @@ func TestSignal(t *testing.T) {
 		err.Write(nil)
 		assert.Nil(t, err.Read())
 	})
+
+	t.Run("update", func(t *testing.T) {
+		count := NewSignal(1)
+
+		count.Update(func(c int) int { return c + 1 })
+		assert.Equal(t, 2, count.Read())
+	})
+
+	t.Run("update does not track", func(t *testing.T) {
+		runs := 0
+
+		count := NewSignal(0)
+		other := NewSignal(0)
+
+		NewEffect(func() {
+			other.Read()
+			count.Update(func(c int) int { return c + 1 })
+			runs++
+		})
+
+		count.Write(10)
+		other.Write(1)
+
+		assert.Equal(t, 2, runs)
+		assert.Equal(t, 11, count.Read())
+	})
+
+	t.Run("concurrent updates", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		count := NewSignal(0)
+
+		for range 100 {
+			wg.Go(func() {
+				count.Update(func(c int) int { return c + 1 })
+			})
+		}
+
+		wg.Wait()
+		assert.Equal(t, 100, count.Read())
+	})
 }
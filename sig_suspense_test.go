@@ -0,0 +1,172 @@
+package sig
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuspense(t *testing.T) {
+	t.Run("counts pending async computeds", func(t *testing.T) {
+		gates := []chan struct{}{make(chan struct{}), make(chan struct{})}
+		counts := make(chan int, 10)
+
+		s := NewSuspense()
+		s.Run(func() error {
+			for _, gate := range gates {
+				NewAsyncComputed(func() (int, error) {
+					<-gate
+					return 0, nil
+				})
+			}
+
+			return nil
+		})
+
+		NewEffect(func() { counts <- s.Pending() })
+
+		assert.Equal(t, 2, <-counts)
+
+		close(gates[0])
+		assert.Equal(t, 1, <-counts)
+
+		close(gates[1])
+		assert.Equal(t, 0, <-counts)
+	})
+
+	t.Run("counts nested owners", func(t *testing.T) {
+		gate := make(chan struct{})
+		done := make(chan struct{})
+
+		s := NewSuspense()
+		s.Run(func() error {
+			return NewOwner().Run(func() error {
+				NewAsyncComputed(func() (int, error) {
+					<-gate
+					return 0, nil
+				})
+
+				return nil
+			})
+		})
+
+		assert.Equal(t, 1, s.Pending())
+
+		NewEffect(func() {
+			if s.Pending() == 0 {
+				close(done)
+			}
+		})
+
+		close(gate)
+		<-done
+	})
+
+	t.Run("holds render effects until all descendants resolve", func(t *testing.T) {
+		var mu sync.Mutex
+		log := []string{}
+		gates := map[string]chan struct{}{
+			"user":  make(chan struct{}),
+			"posts": make(chan struct{}),
+		}
+		rendered := make(chan struct{}, 10)
+
+		s := NewSuspense()
+		s.Run(func() error {
+			user := NewAsyncComputed(func() (string, error) {
+				<-gates["user"]
+				return "bob", nil
+			})
+			posts := NewAsyncComputed(func() (int, error) {
+				<-gates["posts"]
+				return 3, nil
+			})
+
+			NewRenderEffect(func() {
+				u, _ := user.Read()
+
+				mu.Lock()
+				log = append(log, fmt.Sprintf("user %s", u))
+				mu.Unlock()
+
+				rendered <- struct{}{}
+			})
+
+			NewRenderEffect(func() {
+				p, _ := posts.Read()
+
+				mu.Lock()
+				log = append(log, fmt.Sprintf("posts %d", p))
+				mu.Unlock()
+
+				rendered <- struct{}{}
+			})
+
+			return nil
+		})
+
+		close(gates["user"])
+
+		// user resolved, but posts is still pending
+		assert.Eventually(t, func() bool { return s.Pending() == 1 }, time.Second, time.Millisecond)
+		assert.Empty(t, rendered)
+
+		close(gates["posts"])
+		<-rendered
+		<-rendered
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.ElementsMatch(t, []string{
+			"user bob",
+			"posts 3",
+		}, log)
+	})
+
+	t.Run("does not hold user effects", func(t *testing.T) {
+		gate := make(chan struct{})
+		ran := make(chan struct{}, 10)
+
+		s := NewSuspense()
+		s.Run(func() error {
+			NewAsyncComputed(func() (int, error) {
+				<-gate
+				return 0, nil
+			})
+
+			NewEffect(func() { ran <- struct{}{} })
+
+			return nil
+		})
+
+		assert.Len(t, ran, 1)
+		close(gate)
+	})
+
+	t.Run("disposed async computeds are no longer pending", func(t *testing.T) {
+		var o *Owner
+
+		s := NewSuspense()
+		s.Run(func() error {
+			o = NewOwner()
+
+			return o.Run(func() error {
+				NewAsyncComputedContext(func(ctx context.Context) (int, error) {
+					<-ctx.Done()
+					return 0, ctx.Err()
+				})
+
+				return nil
+			})
+		})
+
+		assert.Equal(t, 1, s.Pending())
+
+		o.Dispose()
+		assert.Equal(t, 0, s.Pending())
+	})
+}
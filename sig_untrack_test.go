@@ -2,6 +2,7 @@ package sig
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,3 +26,76 @@ func TestUntrack(t *testing.T) {
 		}, log)
 	})
 }
+
+func TestPeek(t *testing.T) {
+	t.Run("does not track reads", func(t *testing.T) {
+		log := []string{}
+
+		count := NewSignal(0)
+		double := NewComputed(func() int { return count.Read() * 2 })
+
+		NewEffect(func() {
+			log = append(log, fmt.Sprintf("effect %d %d", count.Peek(), double.Peek()))
+		})
+
+		count.Write(10)
+
+		assert.Equal(t, []string{
+			"effect 0 0",
+		}, log)
+	})
+
+	t.Run("recomputes stale computeds", func(t *testing.T) {
+		count := NewSignal(1)
+		double := NewComputed(func() int { return count.Read() * 2 })
+
+		count.Write(10)
+
+		assert.Equal(t, 20, double.Peek())
+	})
+
+	t.Run("snapshot does not recompute", func(t *testing.T) {
+		count := NewSignal(1)
+		double := NewComputed(func() int { return count.Read() * 2 })
+
+		count.Write(10)
+		assert.Equal(t, 2, double.Snapshot())
+
+		double.Read()
+		assert.Equal(t, 20, double.Snapshot())
+	})
+
+	t.Run("from other goroutines", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		rt := NewRuntime()
+
+		var count *Signal[int]
+		var double *Computed[int]
+		rt.Run(func() {
+			count = NewSignal(0)
+			double = NewComputed(func() int { return count.Read() * 2 })
+			NewEffect(func() { double.Read() })
+		})
+
+		wg.Go(func() {
+			for i := range 100 {
+				rt.Run(func() { count.Write(i + 1) })
+			}
+		})
+
+		for range 4 {
+			wg.Go(func() {
+				for range 100 {
+					assert.GreaterOrEqual(t, count.Peek(), 0)
+					assert.Equal(t, 0, double.Snapshot()%2)
+				}
+			})
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, 100, count.Peek())
+		assert.Equal(t, 200, double.Snapshot())
+	})
+}
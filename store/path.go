@@ -0,0 +1,248 @@
+package store
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// normalize checks the path against the type of the value it is walked from, and converts
+// its keys to the ones used by the store: map keys to the map's key type, and indexes to ints.
+// It returns the normalized path and the type of the value at its end, nil if unknown (behind an interface).
+func normalize(t reflect.Type, path []any) ([]any, reflect.Type) {
+	out := make([]any, len(path))
+
+	for i, key := range path {
+		if t == nil {
+			out[i] = key
+			continue
+		}
+
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			f := field(t, key)
+			out[i] = f.Name
+			t = f.Type
+		case reflect.Map:
+			out[i] = mapKey(t, key).Interface()
+			t = t.Elem()
+		case reflect.Slice, reflect.Array:
+			out[i] = index(key)
+			t = t.Elem()
+		case reflect.Interface:
+			out[i] = key
+			t = nil
+		default:
+			panic(fmt.Sprintf("store: cannot get %v from a %s", key, t))
+		}
+	}
+
+	return out, t
+}
+
+// get returns the value at path within v, or an invalid value if there is none.
+func get(v reflect.Value, path []any) reflect.Value {
+	for _, key := range path {
+		if v = child(v, key); !v.IsValid() {
+			break
+		}
+	}
+
+	return v
+}
+
+// child returns the value at key within v, or an invalid value if there is none.
+func child(v reflect.Value, key any) reflect.Value {
+	v = indirect(v)
+	if !v.IsValid() {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		name, _ := key.(string)
+		f, ok := v.Type().FieldByName(name)
+		if !ok || !f.IsExported() {
+			return reflect.Value{}
+		}
+
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			return reflect.Value{}
+		}
+		return fv
+	case reflect.Map:
+		k := reflect.ValueOf(key)
+		if !k.IsValid() || !k.Type().AssignableTo(v.Type().Key()) {
+			return reflect.Value{}
+		}
+		return v.MapIndex(assign(k, v.Type().Key()))
+	case reflect.Slice, reflect.Array:
+		i, ok := key.(int)
+		if !ok || i < 0 || i >= v.Len() {
+			return reflect.Value{}
+		}
+		return v.Index(i)
+	}
+
+	return reflect.Value{}
+}
+
+// with returns a copy of v with the value at path replaced by x, or the map entry at path deleted if x is invalid.
+// The containers along the path are copied rather than modified, so that values read before are left untouched.
+func with(v reflect.Value, path []any, x reflect.Value) reflect.Value {
+	if len(path) == 0 {
+		return assign(x, v.Type())
+	}
+
+	key, rest := path[0], path[1:]
+	t := v.Type()
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem := reflect.Zero(t.Elem())
+		if !v.IsNil() {
+			elem = v.Elem()
+		}
+
+		out := reflect.New(t.Elem())
+		out.Elem().Set(with(elem, path, x))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			panic(fmt.Sprintf("store: cannot set %v within a nil %s", key, t))
+		}
+
+		out := reflect.New(t).Elem()
+		out.Set(with(v.Elem(), path, x))
+		return out
+	case reflect.Struct:
+		f := field(t, key)
+
+		// promoted fields are set through the fields embedding them
+		if len(f.Index) > 1 {
+			names := make([]any, len(f.Index))
+			for i := range f.Index {
+				names[i] = t.FieldByIndex(f.Index[:i+1]).Name
+			}
+			return with(v, append(names, rest...), x)
+		}
+
+		out := reflect.New(t).Elem()
+		out.Set(v)
+
+		fv := out.Field(f.Index[0])
+		fv.Set(with(fv, rest, x))
+		return out
+	case reflect.Map:
+		k := mapKey(t, key)
+
+		out := reflect.MakeMapWithSize(t, v.Len()+1)
+		for iter := v.MapRange(); iter.Next(); {
+			out.SetMapIndex(iter.Key(), iter.Value())
+		}
+
+		if len(rest) == 0 && !x.IsValid() {
+			out.SetMapIndex(k, reflect.Value{})
+			return out
+		}
+
+		elem := v.MapIndex(k)
+		if !elem.IsValid() {
+			elem = reflect.Zero(t.Elem())
+		}
+
+		out.SetMapIndex(k, with(elem, rest, x))
+		return out
+	case reflect.Slice, reflect.Array:
+		i := index(key)
+		if i < 0 || i >= v.Len() {
+			panic(fmt.Sprintf("store: index %d out of range with length %d", i, v.Len()))
+		}
+
+		var out reflect.Value
+		if t.Kind() == reflect.Slice {
+			out = reflect.MakeSlice(t, v.Len(), v.Len())
+			reflect.Copy(out, v)
+		} else {
+			out = reflect.New(t).Elem()
+			out.Set(v)
+		}
+
+		out.Index(i).Set(with(v.Index(i), rest, x))
+		return out
+	}
+
+	panic(fmt.Sprintf("store: cannot set %v within a %s", key, t))
+}
+
+// assign converts x to a value of type t, x being invalid for nil.
+func assign(x reflect.Value, t reflect.Type) reflect.Value {
+	// values given as interfaces are assigned by their dynamic type
+	if x.IsValid() && x.Kind() == reflect.Interface && t.Kind() != reflect.Interface {
+		x = x.Elem()
+	}
+
+	if !x.IsValid() {
+		return reflect.Zero(t)
+	}
+
+	if !x.Type().AssignableTo(t) {
+		panic(fmt.Sprintf("store: cannot use a %s as a %s", x.Type(), t))
+	}
+
+	out := reflect.New(t).Elem()
+	out.Set(x)
+	return out
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+func field(t reflect.Type, key any) reflect.StructField {
+	name, ok := key.(string)
+	if !ok {
+		panic(fmt.Sprintf("store: cannot get %v from a %s, fields are named by strings", key, t))
+	}
+
+	f, ok := t.FieldByName(name)
+	if !ok || !f.IsExported() {
+		panic(fmt.Sprintf("store: %s has no exported field %s", t, name))
+	}
+
+	return f
+}
+
+func mapKey(t reflect.Type, key any) reflect.Value {
+	k := reflect.ValueOf(key)
+	if k.IsValid() && k.Type().AssignableTo(t.Key()) {
+		return assign(k, t.Key())
+	}
+
+	// named key types, e.g. a string used for a map[ID]V
+	if k.IsValid() && k.Kind() == t.Key().Kind() && k.Type().ConvertibleTo(t.Key()) {
+		return k.Convert(t.Key())
+	}
+
+	panic(fmt.Sprintf("store: cannot use %v as a key of a %s", key, t))
+}
+
+func index(key any) int {
+	k := reflect.ValueOf(key)
+	if !k.CanInt() {
+		panic(fmt.Sprintf("store: cannot use %v as an index, indexes are ints", key))
+	}
+
+	return int(k.Int())
+}
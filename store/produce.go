@@ -0,0 +1,297 @@
+package store
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/AnatoleLucet/sig/internal"
+)
+
+// change is a write recorded by Produce, at a path relative to the produced value. An invalid value deletes a map entry.
+type change struct {
+	path  []any
+	value reflect.Value
+}
+
+// Produce calls fn with a draft of the value at c, which it can modify freely, then writes the values
+// it modified to the store in a single batch. The draft is a deep copy, modifying it does not affect the store
+// until fn returns. Only the paths it modified are written, the rest of the store keeps its values.
+// The function must not read the store.
+func Produce[T any](c Cursor, fn func(draft *T)) {
+	f := At[T](c)
+	t := f.tree
+
+	if changed := t.produce(f.path, func(current reflect.Value) reflect.Value {
+		draft := reflect.New(reflect.TypeFor[T]())
+		if current.IsValid() {
+			draft.Elem().Set(deepCopy(current))
+		}
+
+		fn(draft.Interface().(*T))
+		return draft.Elem()
+	}); len(changed) > 0 {
+		t.notify(changed)
+	}
+}
+
+// produce writes the differences between the value at path and the draft returned by fn,
+// and returns the signals of the nodes whose value changed.
+func (t *tree) produce(path []any, fn func(current reflect.Value) reflect.Value) []*internal.Signal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	old := t.root
+	current := get(old, path)
+	draft := fn(current)
+
+	if !current.IsValid() {
+		t.root = with(old, path, draft)
+		return t.changed(old, t.root, path)
+	}
+
+	// the paths of the changes are relative to the value at path
+	changes := diff(current, draft, nil, nil)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var p patch
+	for _, c := range changes {
+		p.add(c.path, c.value)
+	}
+	t.root = with(old, path, p.apply(current))
+
+	var signals []*internal.Signal
+	seen := make(map[*internal.Signal]bool)
+	for _, c := range changes {
+		for _, s := range t.changed(old, t.root, slices.Concat(path, c.path)) {
+			if !seen[s] {
+				seen[s] = true
+				signals = append(signals, s)
+			}
+		}
+	}
+
+	return signals
+}
+
+// patch groups changes by the containers they are made within, so that each container is copied once
+// however many of its values changed.
+type patch struct {
+	replaced bool
+	value    reflect.Value // the value replacing the whole one if replaced, an invalid value deletes a map entry
+	children map[any]*patch
+}
+
+func (p *patch) add(path []any, value reflect.Value) {
+	for _, key := range path {
+		child, ok := p.children[key]
+		if !ok {
+			if p.children == nil {
+				p.children = make(map[any]*patch)
+			}
+
+			child = &patch{}
+			p.children[key] = child
+		}
+
+		p = child
+	}
+
+	p.replaced = true
+	p.value = value
+}
+
+// apply returns a copy of v with the changes of the patch made to it. Like with, the containers along
+// the changed paths are copied rather than modified. The paths are the ones found by diff, they exist within v.
+func (p *patch) apply(v reflect.Value) reflect.Value {
+	t := v.Type()
+
+	if p.replaced {
+		return assign(p.value, t)
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		out := reflect.New(t.Elem())
+		out.Elem().Set(p.apply(v.Elem()))
+		return out
+	case reflect.Interface:
+		out := reflect.New(t).Elem()
+		out.Set(p.apply(v.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(t).Elem()
+		out.Set(v)
+
+		for key, child := range p.children {
+			fv := out.FieldByName(key.(string))
+			fv.Set(child.apply(fv))
+		}
+		return out
+	case reflect.Map:
+		out := reflect.MakeMapWithSize(t, v.Len()+len(p.children))
+		for iter := v.MapRange(); iter.Next(); {
+			out.SetMapIndex(iter.Key(), iter.Value())
+		}
+
+		for key, child := range p.children {
+			k := mapKey(t, key)
+			if child.replaced && !child.value.IsValid() {
+				out.SetMapIndex(k, reflect.Value{})
+				continue
+			}
+
+			elem := v.MapIndex(k)
+			if !elem.IsValid() {
+				elem = reflect.Zero(t.Elem())
+			}
+			out.SetMapIndex(k, child.apply(elem))
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		var out reflect.Value
+		if t.Kind() == reflect.Slice {
+			out = reflect.MakeSlice(t, v.Len(), v.Len())
+			reflect.Copy(out, v)
+		} else {
+			out = reflect.New(t).Elem()
+			out.Set(v)
+		}
+
+		for key, child := range p.children {
+			i := key.(int)
+			out.Index(i).Set(child.apply(v.Index(i)))
+		}
+		return out
+	}
+
+	panic(fmt.Sprintf("store: cannot change the values within a %s", t))
+}
+
+// diff appends the changes turning old into next to changes, old and next being the values at path.
+func diff(old, next reflect.Value, path []any, changes []change) []change {
+	if equal(old, next) {
+		return changes
+	}
+
+	if old.Type() != next.Type() {
+		return append(changes, change{path, next})
+	}
+
+	switch next.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !old.IsNil() && !next.IsNil() && old.Elem().Type() == next.Elem().Type() {
+			return diff(old.Elem(), next.Elem(), path, changes)
+		}
+	case reflect.Struct:
+		t := next.Type()
+
+		// unexported fields can only be written along with the whole struct
+		exported := true
+		for i := range t.NumField() {
+			exported = exported && t.Field(i).IsExported()
+		}
+
+		if exported {
+			for i := range t.NumField() {
+				changes = diff(old.Field(i), next.Field(i), slices.Concat(path, []any{t.Field(i).Name}), changes)
+			}
+			return changes
+		}
+	case reflect.Map:
+		if old.IsNil() || next.IsNil() {
+			break
+		}
+
+		for iter := next.MapRange(); iter.Next(); {
+			key := slices.Concat(path, []any{iter.Key().Interface()})
+			if prev := old.MapIndex(iter.Key()); prev.IsValid() {
+				changes = diff(prev, iter.Value(), key, changes)
+			} else {
+				changes = append(changes, change{key, iter.Value()})
+			}
+		}
+
+		for iter := old.MapRange(); iter.Next(); {
+			if !next.MapIndex(iter.Key()).IsValid() {
+				changes = append(changes, change{slices.Concat(path, []any{iter.Key().Interface()}), reflect.Value{}})
+			}
+		}
+		return changes
+	case reflect.Slice, reflect.Array:
+		if old.Len() != next.Len() || (next.Kind() == reflect.Slice && (old.IsNil() != next.IsNil())) {
+			break
+		}
+
+		for i := range next.Len() {
+			changes = diff(old.Index(i), next.Index(i), slices.Concat(path, []any{i}), changes)
+		}
+		return changes
+	}
+
+	return append(changes, change{path, next})
+}
+
+// deepCopy returns a copy of v sharing no pointer, map or slice with it.
+func deepCopy(v reflect.Value) reflect.Value {
+	t := v.Type()
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+
+		out := reflect.New(t.Elem())
+		out.Elem().Set(deepCopy(v.Elem()))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		out := reflect.New(t).Elem()
+		out.Set(deepCopy(v.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(t).Elem()
+		out.Set(v)
+
+		for i := range t.NumField() {
+			if t.Field(i).IsExported() {
+				out.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		out := reflect.MakeMapWithSize(t, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			out.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		out := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := range v.Len() {
+			out.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := range v.Len() {
+			out.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return out
+	}
+
+	return v
+}
@@ -0,0 +1,181 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AnatoleLucet/sig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProduce(t *testing.T) {
+	t.Run("writes the modified paths", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob", Age: 30}, Items: []int{1, 2}})
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("name %s", At[string](s, "User", "Name").Read()))
+		})
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("age %d", At[int](s, "User", "Age").Read()))
+		})
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("item %d", At[int](s, "Items", 1).Read()))
+		})
+
+		Produce(s, func(draft *state) {
+			draft.User.Age++
+			draft.Items[1] = 20
+		})
+
+		assert.Equal(t, []string{
+			"name bob",
+			"age 30",
+			"item 2",
+			"age 31",
+			"item 20",
+		}, log)
+	})
+
+	t.Run("updates dependents once", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob", Age: 30}})
+
+		sig.NewEffect(func() {
+			u := s.Read().User
+			log = append(log, fmt.Sprintf("%s %d", u.Name, u.Age))
+		})
+
+		Produce(s, func(draft *state) {
+			draft.User.Name = "alice"
+			draft.User.Age = 31
+		})
+
+		assert.Equal(t, []string{
+			"bob 30",
+			"alice 31",
+		}, log)
+	})
+
+	t.Run("does not modify values read before", func(t *testing.T) {
+		s := New(state{Admin: &user{Name: "root"}, Settings: map[string]string{"theme": "dark"}})
+		before := s.Peek()
+
+		Produce(s, func(draft *state) {
+			draft.Admin.Name = "admin"
+			draft.Settings["theme"] = "light"
+
+			assert.Equal(t, "root", before.Admin.Name)
+			assert.Equal(t, "dark", before.Settings["theme"])
+		})
+
+		assert.Equal(t, "root", before.Admin.Name)
+		assert.Equal(t, "dark", before.Settings["theme"])
+		assert.Equal(t, "admin", s.Peek().Admin.Name)
+		assert.Equal(t, "light", s.Peek().Settings["theme"])
+	})
+
+	t.Run("keeps unmodified values", func(t *testing.T) {
+		admin := &user{Name: "root"}
+
+		s := New(state{Admin: admin, User: user{Name: "bob"}})
+
+		Produce(s, func(draft *state) {
+			draft.User.Name = "alice"
+		})
+
+		assert.Same(t, admin, s.Peek().Admin)
+	})
+
+	t.Run("adds and deletes map entries", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{Settings: map[string]string{"theme": "dark"}})
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("theme %q", At[string](s, "Settings", "theme").Read()))
+		})
+
+		Produce(s, func(draft *state) {
+			delete(draft.Settings, "theme")
+			draft.Settings["lang"] = "fr"
+		})
+
+		assert.Equal(t, []string{
+			`theme "dark"`,
+			`theme ""`,
+		}, log)
+		assert.Equal(t, map[string]string{"lang": "fr"}, s.Peek().Settings)
+	})
+
+	t.Run("writes many values within the same containers", func(t *testing.T) {
+		items := make([]int, 1000)
+		settings := map[string]string{"theme": "dark", "lang": "en"}
+
+		s := New(state{Admin: &user{Name: "root", Age: 40}, Settings: settings, Items: items})
+		before := s.Peek()
+
+		Produce(s, func(draft *state) {
+			for i := range draft.Items {
+				draft.Items[i] = i
+			}
+
+			draft.Admin.Name = "admin"
+			draft.Admin.Age++
+			draft.Settings["theme"] = "light"
+			draft.Settings["size"] = "large"
+			delete(draft.Settings, "lang")
+		})
+
+		after := s.Peek()
+		for i, item := range after.Items {
+			assert.Equal(t, i, item)
+		}
+		assert.Equal(t, user{Name: "admin", Age: 41}, *after.Admin)
+		assert.Equal(t, map[string]string{"theme": "light", "size": "large"}, after.Settings)
+
+		assert.Equal(t, make([]int, 1000), before.Items)
+		assert.Equal(t, user{Name: "root", Age: 40}, *before.Admin)
+		assert.Equal(t, map[string]string{"theme": "dark", "lang": "en"}, before.Settings)
+	})
+
+	t.Run("grows slices", func(t *testing.T) {
+		s := New(state{Items: []int{1}})
+
+		Produce(s, func(draft *state) {
+			draft.Items = append(draft.Items, 2)
+		})
+
+		assert.Equal(t, []int{1, 2}, s.Peek().Items)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		s := New(state{User: user{Name: "bob", Tags: []string{"a"}}})
+
+		Produce(At[user](s, "User"), func(draft *user) {
+			draft.Tags = append(draft.Tags, "b")
+		})
+
+		assert.Equal(t, []string{"a", "b"}, s.Peek().User.Tags)
+	})
+
+	t.Run("ignores drafts left untouched", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob"}})
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("name %s", s.Read().User.Name))
+		})
+
+		Produce(s, func(draft *state) {
+			draft.User.Name = "bob"
+		})
+
+		assert.Equal(t, []string{
+			"name bob",
+		}, log)
+	})
+}
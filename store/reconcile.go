@@ -0,0 +1,142 @@
+package store
+
+import "reflect"
+
+type ReconcileOptions struct {
+	// Key is the name of the field matching slice elements of the old and new values, e.g. "ID".
+	// Without a key, elements are matched by index.
+	Key string
+
+	// KeyFunc returns the key matching a slice element, it takes precedence over Key.
+	// Keys must be comparable.
+	KeyFunc func(elem any) any
+}
+
+// Reconcile writes v to the value at c, only updating the dependents of the paths whose value actually changed.
+// The parts of v equal to the current value are replaced by the current ones, so that unchanged pointers,
+// maps and slices keep their identity, even when slice elements are moved around and matched by key.
+func Reconcile[T any](c Cursor, v T, options ...ReconcileOptions) {
+	var opts ReconcileOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	f := At[T](c)
+	f.tree.write(f.path, func(old reflect.Value) reflect.Value {
+		return opts.reconcile(old, reflect.ValueOf(&v).Elem())
+	})
+}
+
+// reconcile returns next, with the values equal to the ones at the same place in old replaced by them.
+func (o ReconcileOptions) reconcile(old, next reflect.Value) reflect.Value {
+	if !old.IsValid() || !next.IsValid() || old.Type() != next.Type() {
+		return next
+	}
+
+	if equal(old, next) {
+		return old
+	}
+
+	t := next.Type()
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if old.IsNil() || next.IsNil() {
+			return next
+		}
+
+		out := reflect.New(t.Elem())
+		out.Elem().Set(o.reconcile(old.Elem(), next.Elem()))
+		return out
+	case reflect.Interface:
+		if old.IsNil() || next.IsNil() {
+			return next
+		}
+
+		out := reflect.New(t).Elem()
+		out.Set(o.reconcile(old.Elem(), next.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(t).Elem()
+		out.Set(next)
+
+		for i := range t.NumField() {
+			if t.Field(i).IsExported() {
+				out.Field(i).Set(o.reconcile(old.Field(i), next.Field(i)))
+			}
+		}
+		return out
+	case reflect.Map:
+		if next.IsNil() {
+			return next
+		}
+
+		out := reflect.MakeMapWithSize(t, next.Len())
+		for iter := next.MapRange(); iter.Next(); {
+			out.SetMapIndex(iter.Key(), o.reconcile(old.MapIndex(iter.Key()), iter.Value()))
+		}
+		return out
+	case reflect.Slice:
+		if next.IsNil() {
+			return next
+		}
+
+		// index of the old elements by key, if they have one
+		keyed := make(map[any]int)
+		for i := range old.Len() {
+			if key, ok := o.key(old.Index(i)); ok {
+				if _, dup := keyed[key]; !dup {
+					keyed[key] = i
+				}
+			}
+		}
+
+		out := reflect.MakeSlice(t, next.Len(), next.Len())
+		for i := range next.Len() {
+			elem := next.Index(i)
+
+			var match reflect.Value
+			if key, ok := o.key(elem); ok {
+				if j, found := keyed[key]; found {
+					match = old.Index(j)
+				}
+			} else if i < old.Len() {
+				match = old.Index(i)
+			}
+
+			out.Index(i).Set(o.reconcile(match, elem))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := range next.Len() {
+			out.Index(i).Set(o.reconcile(old.Index(i), next.Index(i)))
+		}
+		return out
+	}
+
+	return next
+}
+
+// key returns the key of a slice element, if it has one.
+func (o ReconcileOptions) key(elem reflect.Value) (any, bool) {
+	if o.KeyFunc != nil {
+		return o.KeyFunc(elem.Interface()), true
+	}
+
+	if o.Key == "" {
+		return nil, false
+	}
+
+	v := indirect(elem)
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	f := child(v, o.Key)
+	if !f.IsValid() {
+		return nil, false
+	}
+
+	return f.Interface(), true
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AnatoleLucet/sig"
+	"github.com/stretchr/testify/assert"
+)
+
+type todo struct {
+	ID    int
+	Title string
+	Done  bool
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("only updates changed paths", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob", Age: 30}, Settings: map[string]string{"theme": "dark"}})
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("name %s", At[string](s, "User", "Name").Read()))
+		})
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("age %d", At[int](s, "User", "Age").Read()))
+		})
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("theme %s", At[string](s, "Settings", "theme").Read()))
+		})
+
+		Reconcile(s, state{User: user{Name: "bob", Age: 31}, Settings: map[string]string{"theme": "dark"}})
+
+		assert.Equal(t, []string{
+			"name bob",
+			"age 30",
+			"theme dark",
+			"age 31",
+		}, log)
+		assert.Equal(t, 31, s.Peek().User.Age)
+	})
+
+	t.Run("updates changed paths once", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob", Age: 30}})
+
+		sig.NewEffect(func() {
+			u := s.Read().User
+			log = append(log, fmt.Sprintf("%s %d", u.Name, u.Age))
+		})
+
+		Reconcile(s, state{User: user{Name: "alice", Age: 31}})
+
+		assert.Equal(t, []string{
+			"bob 30",
+			"alice 31",
+		}, log)
+	})
+
+	t.Run("keeps unchanged values", func(t *testing.T) {
+		admin := &user{Name: "root"}
+		settings := map[string]string{"theme": "dark"}
+
+		s := New(state{Admin: admin, Settings: settings, User: user{Name: "bob"}})
+		Reconcile(s, state{Admin: &user{Name: "root"}, Settings: map[string]string{"theme": "dark"}, User: user{Name: "alice"}})
+
+		assert.Same(t, admin, s.Peek().Admin)
+		assert.Equal(t, fmt.Sprintf("%p", settings), fmt.Sprintf("%p", s.Peek().Settings))
+		assert.Equal(t, "alice", s.Peek().User.Name)
+	})
+
+	t.Run("matches slice elements by key", func(t *testing.T) {
+		first := &todo{ID: 1, Title: "first"}
+		second := &todo{ID: 2, Title: "second"}
+
+		s := New([]*todo{first, second})
+		Reconcile(s, []*todo{{ID: 3, Title: "third"}, {ID: 2, Title: "second"}, {ID: 1, Title: "first", Done: true}}, ReconcileOptions{Key: "ID"})
+
+		todos := s.Peek()
+		assert.Equal(t, "third", todos[0].Title)
+		assert.Same(t, second, todos[1])
+		assert.NotSame(t, first, todos[2])
+		assert.True(t, todos[2].Done)
+		assert.False(t, first.Done)
+	})
+
+	t.Run("matches slice elements with a key function", func(t *testing.T) {
+		second := &todo{ID: 2, Title: "second"}
+
+		s := New([]*todo{{ID: 1, Title: "first"}, second})
+		Reconcile(s, []*todo{{ID: 2, Title: "second"}}, ReconcileOptions{
+			KeyFunc: func(elem any) any { return elem.(*todo).ID },
+		})
+
+		assert.Same(t, second, s.Peek()[0])
+	})
+
+	t.Run("reconciles fields", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob", Age: 30}})
+		u := At[user](s, "User")
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("name %s", At[string](u, "Name").Read()))
+		})
+
+		Reconcile(u, user{Name: "bob", Age: 40})
+		Reconcile(u, user{Name: "alice", Age: 40})
+
+		assert.Equal(t, []string{
+			"name bob",
+			"name alice",
+		}, log)
+	})
+
+	t.Run("ignores equal values", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{Items: []int{1, 2}})
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("items %v", s.Read().Items))
+		})
+
+		Reconcile(s, state{Items: []int{1, 2}})
+
+		assert.Equal(t, []string{
+			"items [1 2]",
+		}, log)
+	})
+}
@@ -0,0 +1,285 @@
+// Package store provides reactive stores: trees of structs, maps and slices tracked path by path.
+//
+// Unlike a signal holding the whole tree, reading a value from a store only subscribes to the path it was read from,
+// so that writing a field only updates the dependents of that field, of the values containing it, and of the values within it that changed.
+// A store holds immutable values: writes copy the maps, slices and structs along the written path instead of modifying them,
+// so that values read from the store can be kept around safely.
+package store
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/AnatoleLucet/sig/internal"
+)
+
+// Cursor is implemented by stores and their fields, values are read and written at a path relative to it with At.
+type Cursor interface {
+	cursor() (*tree, []any)
+}
+
+type Store[T any] struct {
+	Field[T]
+}
+
+// New creates a store holding v.
+// The store takes ownership of v, the maps, slices and pointers within it must not be modified afterwards.
+func New[T any](v T) *Store[T] {
+	root := reflect.New(reflect.TypeFor[T]()).Elem()
+	root.Set(reflect.ValueOf(&v).Elem())
+
+	t := &tree{
+		runtime: internal.GetRuntime(),
+		typ:     root.Type(),
+		root:    root,
+	}
+	t.nodes = t.newNode()
+
+	return &Store[T]{Field[T]{t, nil}}
+}
+
+// Field is a value within a store, at a given path.
+type Field[T any] struct {
+	tree *tree
+	path []any
+}
+
+// At returns the field at path within c. Struct fields are named by strings, slice and array elements by indexes,
+// and map entries by keys. Pointers and interfaces along the path are dereferenced.
+// It panics if the path does not match the types of the store, or if the value at path is not a T.
+func At[T any](c Cursor, path ...any) *Field[T] {
+	t, base := c.cursor()
+
+	full, typ := normalize(t.typ, append(slices.Clip(base), path...))
+	if want := reflect.TypeFor[T](); typ != nil && typ != want && !(want.Kind() == reflect.Interface && typ.Implements(want)) {
+		panic(fmt.Sprintf("store: value at %v is a %s, not a %s", full, typ, want))
+	}
+
+	return &Field[T]{t, full}
+}
+
+func (f *Field[T]) cursor() (*tree, []any) {
+	return f.tree, f.path
+}
+
+// Path returns the path of the field from the root of the store.
+func (f *Field[T]) Path() []any {
+	return slices.Clone(f.path)
+}
+
+// Read the current value of the field, tracking it if within a reactive context.
+// The zero value is returned if the path does not exist, e.g. a missing map key.
+func (f *Field[T]) Read() T {
+	f.tree.track(f.path)
+	return f.Peek()
+}
+
+// Peek the current value of the field without tracking it.
+func (f *Field[T]) Peek() T {
+	f.tree.mu.RLock()
+	defer f.tree.mu.RUnlock()
+
+	return value[T](get(f.tree.root, f.path))
+}
+
+// Write a new value to the field, updating the dependents of the paths whose value changed.
+// Missing map entries and nil pointers along the path are created.
+func (f *Field[T]) Write(v T) {
+	f.Update(func(T) T { return v })
+}
+
+// Update the field with a function of its current value, without tracking the read.
+// The read and the write are atomic. The function must not read the store.
+func (f *Field[T]) Update(fn func(T) T) {
+	f.tree.write(f.path, func(old reflect.Value) reflect.Value {
+		v := fn(value[T](old))
+		return reflect.ValueOf(&v).Elem()
+	})
+}
+
+func value[T any](v reflect.Value) T {
+	if !v.IsValid() {
+		var zero T
+		return zero
+	}
+
+	return v.Interface().(T)
+}
+
+type tree struct {
+	// the runtime the store was created in, the signals of its nodes belong to it
+	runtime *internal.Runtime
+
+	mu   sync.RWMutex
+	typ  reflect.Type
+	root reflect.Value
+
+	nodesMu sync.Mutex
+	nodes   *node
+}
+
+// node is a path within the store that is being tracked, its signal is written to whenever the value at the path changes.
+// Nodes only exist while they, or the nodes within them, have subscribers.
+type node struct {
+	signal   *internal.Signal
+	children map[any]*node
+}
+
+func (t *tree) newNode() *node {
+	return &node{signal: t.runtime.NewSignal(uint64(0))}
+}
+
+// track subscribes the current computation to the value at path, if reads are tracked.
+// The nodes along the path are created as needed, and pruned once the computation no longer depends on them.
+func (t *tree) track(path []any) {
+	comp := internal.GetRuntime().TrackingComputation()
+	if comp == nil {
+		return
+	}
+
+	// subscribe before releasing the nodes, so that the node is not pruned in between
+	t.nodesMu.Lock()
+	t.node(path).signal.Read()
+	t.nodesMu.Unlock()
+
+	comp.OnCleanup(func() { t.prune(path, comp) })
+}
+
+// node returns the node at path, creating it if needed. nodesMu must be held.
+func (t *tree) node(path []any) *node {
+	n := t.nodes
+	for _, key := range path {
+		child, ok := n.children[key]
+		if !ok {
+			if n.children == nil {
+				n.children = make(map[any]*node)
+			}
+
+			child = t.newNode()
+			n.children[key] = child
+		}
+
+		n = child
+	}
+
+	return n
+}
+
+// prune removes the node at path, and the nodes containing it, once they have neither children
+// nor subscribers other than comp, which is being cleaned up.
+func (t *tree) prune(path []any, comp *internal.Computed) {
+	t.nodesMu.Lock()
+	defer t.nodesMu.Unlock()
+
+	nodes := []*node{t.nodes}
+	for _, key := range path {
+		child, ok := nodes[len(nodes)-1].children[key]
+		if !ok {
+			return
+		}
+		nodes = append(nodes, child)
+	}
+
+	for i := len(path); i > 0; i-- {
+		n := nodes[i]
+		if len(n.children) > 0 || subscribed(n.signal, comp) {
+			return
+		}
+
+		parent := nodes[i-1]
+		delete(parent.children, path[i-1])
+		if len(parent.children) == 0 {
+			parent.children = nil
+		}
+	}
+}
+
+// subscribed reports whether s has subscribers other than comp.
+func subscribed(s *internal.Signal, comp *internal.Computed) bool {
+	for sub := range s.Subs() {
+		if sub != comp {
+			return true
+		}
+	}
+
+	return false
+}
+
+// write replaces the value at path with the result of fn called with the current one,
+// then updates the dependents of every path whose value changed, in a single batch.
+func (t *tree) write(path []any, fn func(old reflect.Value) reflect.Value) {
+	if changed := t.set(path, fn); len(changed) > 0 {
+		t.notify(changed)
+	}
+}
+
+// set replaces the value at path with the result of fn, and returns the signals of the nodes whose value changed.
+func (t *tree) set(path []any, fn func(old reflect.Value) reflect.Value) []*internal.Signal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	old := t.root
+	current := get(old, path)
+	next := fn(current)
+
+	if equal(current, next) {
+		return nil
+	}
+
+	t.root = with(old, path, next)
+	return t.changed(old, t.root, path)
+}
+
+// changed returns the signals of the nodes whose value differs between old and next, after a write at path:
+// the nodes containing the written value, and the nodes within it whose value changed.
+func (t *tree) changed(old, next reflect.Value, path []any) []*internal.Signal {
+	t.nodesMu.Lock()
+	defer t.nodesMu.Unlock()
+
+	var signals []*internal.Signal
+
+	n := t.nodes
+	for _, key := range path {
+		signals = append(signals, n.signal)
+
+		if n = n.children[key]; n == nil {
+			return signals
+		}
+	}
+
+	var diff func(n *node, old, next reflect.Value)
+	diff = func(n *node, old, next reflect.Value) {
+		if equal(old, next) {
+			return
+		}
+
+		signals = append(signals, n.signal)
+		for key, child := range n.children {
+			diff(child, get(old, []any{key}), get(next, []any{key}))
+		}
+	}
+	diff(n, get(old, path), get(next, path))
+
+	return signals
+}
+
+// notify writes to the given signals in a single batch, updating their subscribers once.
+func (t *tree) notify(signals []*internal.Signal) {
+	r := internal.GetRuntime()
+
+	r.NewBatch(func() {
+		for _, s := range signals {
+			s.Update(func(v any) any { return v.(uint64) + 1 })
+		}
+	})
+}
+
+func equal(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
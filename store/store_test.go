@@ -0,0 +1,336 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AnatoleLucet/sig"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ sig.Writable[int] = (*Store[int])(nil)
+	_ sig.Writable[int] = (*Field[int])(nil)
+)
+
+type user struct {
+	Name string
+	Age  int
+	Tags []string
+}
+
+type state struct {
+	User     user
+	Admin    *user
+	Settings map[string]string
+	Items    []int
+}
+
+func TestStore(t *testing.T) {
+	t.Run("reads and writes fields", func(t *testing.T) {
+		s := New(state{User: user{Name: "bob", Age: 30}})
+		name := At[string](s, "User", "Name")
+
+		assert.Equal(t, "bob", name.Read())
+
+		name.Write("alice")
+		assert.Equal(t, "alice", name.Read())
+		assert.Equal(t, "alice", s.Read().User.Name)
+	})
+
+	t.Run("only updates readers of the written path", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob", Age: 30}})
+		name := At[string](s, "User", "Name")
+		age := At[int](s, "User", "Age")
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("name %s", name.Read()))
+		})
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("age %d", age.Read()))
+		})
+
+		name.Write("alice")
+		age.Write(31)
+
+		assert.Equal(t, []string{
+			"name bob",
+			"age 30",
+			"name alice",
+			"age 31",
+		}, log)
+	})
+
+	t.Run("updates readers of containing values", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob"}})
+		u := At[user](s, "User")
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("user %s", u.Read().Name))
+		})
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("store %s", s.Read().User.Name))
+		})
+
+		At[string](s, "User", "Name").Write("alice")
+
+		assert.Equal(t, []string{
+			"user bob",
+			"store bob",
+			"store alice",
+			"user alice",
+		}, log)
+	})
+
+	t.Run("updates readers of changed values within the written one", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob", Age: 30}})
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("name %s", At[string](s, "User", "Name").Read()))
+		})
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("age %d", At[int](s, "User", "Age").Read()))
+		})
+
+		At[user](s, "User").Write(user{Name: "bob", Age: 31})
+
+		assert.Equal(t, []string{
+			"name bob",
+			"age 30",
+			"age 31",
+		}, log)
+	})
+
+	t.Run("ignores writes of the same value", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob", Tags: []string{"a"}}})
+		u := At[user](s, "User")
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("user %s", u.Read().Name))
+		})
+
+		u.Write(user{Name: "bob", Tags: []string{"a"}})
+
+		assert.Equal(t, []string{
+			"user bob",
+		}, log)
+	})
+
+	t.Run("fields relative to other fields", func(t *testing.T) {
+		s := New(state{User: user{Name: "bob"}})
+		u := At[user](s, "User")
+		name := At[string](u, "Name")
+
+		assert.Equal(t, []any{"User", "Name"}, name.Path())
+
+		name.Write("alice")
+		assert.Equal(t, "alice", u.Read().Name)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		s := New(state{User: user{Age: 30}})
+		age := At[int](s, "User", "Age")
+
+		age.Update(func(a int) int { return a + 1 })
+		assert.Equal(t, 31, age.Peek())
+	})
+
+	t.Run("batches writes", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob", Age: 30}})
+
+		sig.NewEffect(func() {
+			u := At[user](s, "User").Read()
+			log = append(log, fmt.Sprintf("%s %d", u.Name, u.Age))
+		})
+
+		sig.NewBatch(func() {
+			At[string](s, "User", "Name").Write("alice")
+			At[int](s, "User", "Age").Write(31)
+		})
+
+		assert.Equal(t, []string{
+			"bob 30",
+			"alice 31",
+		}, log)
+	})
+
+	t.Run("only keeps nodes for tracked paths", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{User: user{Name: "bob", Age: 30}})
+		name := At[string](s, "User", "Name")
+
+		name.Read()
+		At[int](s, "User", "Age").Read()
+		assert.Equal(t, 0, countNodes(s.tree.nodes))
+
+		o := sig.NewOwner()
+		o.Run(func() error {
+			sig.NewEffect(func() {
+				log = append(log, fmt.Sprintf("name %s", name.Read()))
+			})
+			return nil
+		})
+		assert.Equal(t, 2, countNodes(s.tree.nodes))
+
+		name.Write("alice")
+		name.Write("carol")
+		assert.Equal(t, 2, countNodes(s.tree.nodes))
+
+		o.Dispose()
+		assert.Equal(t, 0, countNodes(s.tree.nodes))
+
+		assert.Equal(t, []string{
+			"name bob",
+			"name alice",
+			"name carol",
+		}, log)
+	})
+}
+
+func TestStoreContainers(t *testing.T) {
+	t.Run("maps", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{Settings: map[string]string{"theme": "dark"}})
+		theme := At[string](s, "Settings", "theme")
+		lang := At[string](s, "Settings", "lang")
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("theme %s", theme.Read()))
+		})
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("lang %q", lang.Read()))
+		})
+
+		lang.Write("fr")
+
+		assert.Equal(t, []string{
+			"theme dark",
+			`lang ""`,
+			`lang "fr"`,
+		}, log)
+		assert.Equal(t, map[string]string{"theme": "dark", "lang": "fr"}, s.Peek().Settings)
+	})
+
+	t.Run("slices", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{Items: []int{1, 2, 3}})
+		first := At[int](s, "Items", 0)
+		second := At[int](s, "Items", 1)
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("first %d", first.Read()))
+		})
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("second %d", second.Read()))
+		})
+
+		second.Write(20)
+
+		assert.Equal(t, []string{
+			"first 1",
+			"second 2",
+			"second 20",
+		}, log)
+	})
+
+	t.Run("slice shrinking", func(t *testing.T) {
+		log := []string{}
+
+		s := New(state{Items: []int{1, 2, 3}})
+		last := At[int](s, "Items", 2)
+
+		sig.NewEffect(func() {
+			log = append(log, fmt.Sprintf("last %d", last.Read()))
+		})
+
+		At[[]int](s, "Items").Write([]int{1, 2})
+
+		assert.Equal(t, []string{
+			"last 3",
+			"last 0",
+		}, log)
+	})
+
+	t.Run("pointers", func(t *testing.T) {
+		s := New(state{})
+		name := At[string](s, "Admin", "Name")
+
+		assert.Equal(t, "", name.Read())
+
+		name.Write("root")
+		assert.Equal(t, "root", s.Peek().Admin.Name)
+	})
+
+	t.Run("leaves values read before untouched", func(t *testing.T) {
+		s := New(state{Admin: &user{Name: "root"}, Items: []int{1, 2}})
+
+		before := s.Peek()
+		At[string](s, "Admin", "Name").Write("admin")
+		At[int](s, "Items", 0).Write(10)
+
+		assert.Equal(t, "root", before.Admin.Name)
+		assert.Equal(t, []int{1, 2}, before.Items)
+		assert.Equal(t, "admin", s.Peek().Admin.Name)
+		assert.Equal(t, []int{10, 2}, s.Peek().Items)
+	})
+
+	t.Run("interfaces", func(t *testing.T) {
+		s := New(map[string]any{"count": 1})
+		count := At[any](s, "count")
+
+		count.Write(2)
+		assert.Equal(t, 2, count.Read())
+	})
+}
+
+func TestStorePaths(t *testing.T) {
+	t.Run("panics on unknown fields", func(t *testing.T) {
+		s := New(state{})
+
+		assert.PanicsWithValue(t, "store: store.user has no exported field Email", func() {
+			At[string](s, "User", "Email")
+		})
+	})
+
+	t.Run("panics on mismatching types", func(t *testing.T) {
+		s := New(state{})
+
+		assert.PanicsWithValue(t, "store: value at [User Age] is a int, not a string", func() {
+			At[string](s, "User", "Age")
+		})
+	})
+
+	t.Run("panics on out of range writes", func(t *testing.T) {
+		s := New(state{Items: []int{1}})
+
+		assert.PanicsWithValue(t, "store: index 3 out of range with length 1", func() {
+			At[int](s, "Items", 3).Write(1)
+		})
+
+		At[int](s, "Items", 0).Write(2)
+		assert.Equal(t, []int{2}, s.Peek().Items)
+	})
+}
+
+// countNodes returns the number of nodes within n.
+func countNodes(n *node) int {
+	count := 0
+	for _, child := range n.children {
+		count += 1 + countNodes(child)
+	}
+
+	return count
+}